@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v2"
+	otlh "github.com/xifanyan/otlh/pkg"
+)
+
+// readBody returns the content of the --input file, or of stdin for "-".
+func readBody(input string) (string, error) {
+	var data []byte
+	var err error
+
+	if input == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(input)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if !json.Valid(data) {
+		return "", fmt.Errorf("request body in %s is not valid JSON", input)
+	}
+	return string(data), nil
+}
+
+func apiCall(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return fmt.Errorf("usage: otlh api <METHOD> <path>")
+	}
+
+	method, err := otlh.ParseMethod(ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	var opts []otlh.Options
+	if input := ctx.String("input"); input != "" {
+		body, err := readBody(input)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, otlh.NewBodyOptions().WithBody(body))
+	}
+
+	resp, err := callAPI(ctx.Context, NewClient(ctx), method, ctx.Args().Get(1), ctx.Bool("paginate"), ctx.Int("pageSize"), opts...)
+	if err != nil {
+		return err
+	}
+
+	if len(resp) == 0 {
+		return nil
+	}
+
+	// pretty print JSON, anything else as received
+	buf := new(bytes.Buffer)
+	if json.Indent(buf, resp, "", "  ") != nil {
+		os.Stdout.Write(resp)
+		return nil
+	}
+
+	fmt.Println(buf.String())
+	return nil
+}
+
+// callAPI sends method to path, with paginate every page of a GET merged into one response.
+func callAPI(ctx context.Context, client *otlh.Client, method otlh.Method, path string, paginate bool, pageSize int, opts ...otlh.Options) ([]byte, error) {
+	if paginate && method != otlh.GET {
+		return nil, fmt.Errorf("--paginate only works with GET")
+	}
+
+	req := otlh.NewRawRequest(method, client.Tenant(), path)
+
+	if paginate {
+		return client.GetAllPagesContext(ctx, req, otlh.NewListOptions().WithPageSize(pageSize))
+	}
+	return client.SendContext(ctx, req, opts...)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestCallAPI(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	for i := range 12 {
+		srv.AddFolder(otlh.Folder{Name: fmt.Sprintf("Folder %02d", i)})
+	}
+
+	client := srv.Client()
+
+	tests := []struct {
+		name         string
+		method       otlh.Method
+		path         string
+		paginate     bool
+		wantFolders  int
+		wantRequests int
+		wantErr      bool
+	}{
+		{name: "first page", method: otlh.GET, path: "folders", wantFolders: 5, wantRequests: 1},
+		{name: "paginate", method: otlh.GET, path: "/folders", paginate: true, wantFolders: 12, wantRequests: 3},
+		{name: "paginate with a full path", method: otlh.GET, path: "/t/{tenant}/api/{version}/folders", paginate: true, wantFolders: 12, wantRequests: 3},
+		{name: "paginate only with GET", method: otlh.POST, path: "folders", paginate: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := len(srv.Requests())
+
+			// without paginate the page size is left to the path, as the user typed it
+			path := tt.path
+			if !tt.paginate {
+				path += "?page_size=5"
+			}
+			resp, err := callAPI(context.Background(), client, tt.method, path, tt.paginate, 5)
+
+			if n := len(srv.Requests()) - sent; n != tt.wantRequests {
+				t.Fatalf("got %d requests, want %d", n, tt.wantRequests)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("got no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var list struct {
+				Embedded struct {
+					Folders []otlh.Folder `json:"folders"`
+				} `json:"_embedded"`
+			}
+			if err = json.Unmarshal(resp, &list); err != nil {
+				t.Fatal(err)
+			}
+			if len(list.Embedded.Folders) != tt.wantFolders {
+				t.Fatalf("got %d folders, want %d", len(list.Embedded.Folders), tt.wantFolders)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+
+	"github.com/urfave/cli/v2"
+	otlh "github.com/xifanyan/otlh/pkg"
+)
+
+// operator returns the --operator flag, or user@host of the current OS user.
+func operator(ctx *cli.Context) string {
+	if op := ctx.String("operator"); op != "" {
+		return op
+	}
+
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+
+	if host, err := os.Hostname(); err == nil {
+		return name + "@" + host
+	}
+	return name
+}
+
+// auditJournalPath returns the journal named in the first argument, else the one of --auditJournal or the profile.
+func auditJournalPath(ctx *cli.Context) (string, error) {
+	if path := ctx.Args().First(); path != "" {
+		return path, nil
+	}
+
+	cfg, _, err := resolveClientConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if cfg.AuditJournal == "" {
+		return "", fmt.Errorf("no audit journal given, pass its path or set --auditJournal")
+	}
+	return cfg.AuditJournal, nil
+}
+
+func auditVerify(ctx *cli.Context) error {
+	path, err := auditJournalPath(ctx)
+	if err != nil {
+		return err
+	}
+
+	var anchors []otlh.AuditHead
+	for _, s := range ctx.StringSlice("anchor") {
+		anchor, err := otlh.ParseAuditHead(s)
+		if err != nil {
+			return err
+		}
+		anchors = append(anchors, anchor)
+	}
+
+	head, err := otlh.VerifyAuditJournal(path, anchors...)
+	if err != nil {
+		return fmt.Errorf("audit journal %s failed verification after %d valid entries: %w", path, head.Seq, err)
+	}
+
+	fmt.Printf("audit journal %s: %d entries, hash chain unbroken\n", path, head.Seq)
+	fmt.Printf("head: %s\n", head)
+
+	if len(anchors) == 0 {
+		fmt.Println("entries removed from the end, or a journal rewritten with new hashes, are only detected against an earlier head: record the head above outside the journal and pass it with --anchor next time")
+	} else {
+		fmt.Printf("matches %d anchors\n", len(anchors))
+	}
+	return nil
+}
+
+func auditShow(ctx *cli.Context) error {
+	path, err := auditJournalPath(ctx)
+	if err != nil {
+		return err
+	}
+
+	entries, err := otlh.ReadAuditJournal(path)
+	if err != nil {
+		return err
+	}
+
+	matterID := ctx.Int("matterID")
+	holdID := ctx.Int("holdID")
+
+	selected := []otlh.AuditEntry{}
+	for _, entry := range entries {
+		if matterID > 0 && !entry.HasMatter(matterID) {
+			continue
+		}
+		if holdID > 0 && !entry.HasHold(holdID) {
+			continue
+		}
+		selected = append(selected, entry)
+	}
+
+	return otlh.NewPrinter().JSON().Build().Print(selected)
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
-	"encoding/json"
+	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	otlh "github.com/xifanyan/otlh/pkg"
 	importer "github.com/xifanyan/otlh/pkg/importer"
@@ -13,14 +15,6 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
-type ClientConfig struct {
-	Domain    string `json:"domain"`
-	Port      int    `json:"port"`
-	HttpProxy string `json:"httpProxy"`
-	Tenant    string `json:"tenant"`
-	AuthToken string `json:"authToken"`
-}
-
 func checkTimezone(tz string) error {
 	allowedTimezones := map[string]bool{
 		"CST": true,
@@ -37,6 +31,75 @@ func checkTimezone(tz string) error {
 }
 
 var (
+	ConfigCmd = &cli.Command{
+		Name:  "config",
+		Usage: "inspect and switch the profiles of the config file",
+		Subcommands: []*cli.Command{
+			ConfigListCmd,
+			ConfigShowCmd,
+			ConfigUseCmd,
+		},
+	}
+
+	ConfigListCmd = &cli.Command{
+		Name:     "list",
+		Category: "config",
+		Usage:    "list the profiles, * marks the current one",
+		Action:   execute,
+	}
+
+	ConfigShowCmd = &cli.Command{
+		Name:      "show",
+		Category:  "config",
+		Usage:     "show the settings of a profile, the active one by default",
+		ArgsUsage: "[profile]",
+		Action:    execute,
+	}
+
+	ConfigUseCmd = &cli.Command{
+		Name:      "use",
+		Category:  "config",
+		Usage:     "make a profile the current one",
+		ArgsUsage: "<profile>",
+		Action:    execute,
+	}
+
+	APICmd = &cli.Command{
+		Name:      "api",
+		Category:  "api",
+		Usage:     "send a request to any endpoint of the API and print the response",
+		ArgsUsage: "<METHOD> <path>, e.g. GET legal_holds/123/stats",
+		Flags:     DefaultAPIOptions,
+		Action:    execute,
+	}
+
+	AuditCmd = &cli.Command{
+		Name:  "audit",
+		Usage: "check and search the audit journal",
+		Subcommands: []*cli.Command{
+			AuditVerifyCmd,
+			AuditShowCmd,
+		},
+	}
+
+	AuditVerifyCmd = &cli.Command{
+		Name:      "verify",
+		Category:  "audit",
+		Usage:     "check the hash chain of the journal and print its head, against earlier heads with --anchor",
+		ArgsUsage: "[journal], defaults to --auditJournal",
+		Flags:     DefaultAuditVerifyOptions,
+		Action:    execute,
+	}
+
+	AuditShowCmd = &cli.Command{
+		Name:      "show",
+		Category:  "audit",
+		Usage:     "print the entries of the journal, optionally only those of a matter or hold",
+		ArgsUsage: "[journal], defaults to --auditJournal",
+		Flags:     DefaultAuditShowOptions,
+		Action:    execute,
+	}
+
 	CreateCmd = &cli.Command{
 		Name: "create",
 		Subcommands: []*cli.Command{
@@ -45,6 +108,17 @@ var (
 		},
 	}
 
+	DeleteCmd = &cli.Command{
+		Name: "delete",
+		Subcommands: []*cli.Command{
+			DeleteFolderCmd,
+			DeleteMatterCmd,
+			DeleteCustodianCmd,
+			DeleteCustodianGroupCmd,
+			DeleteQuestionnaireCmd,
+		},
+	}
+
 	GetCmd = &cli.Command{
 		Name: "get",
 		Subcommands: []*cli.Command{
@@ -56,6 +130,9 @@ var (
 			GetLegalholdsCmd,
 			GetSilentholdsCmd,
 			GetQuestionnairesCmd,
+			GetStatsCmd,
+			GetHistoryCmd,
+			GetNoticeCmd,
 		},
 	}
 
@@ -81,6 +158,7 @@ var (
 			HoldName,
 			MatterName,
 			CheckInputOnly,
+			Prefetch,
 		},
 		Before: func(c *cli.Context) error {
 			return checkTimezone(c.String("timezone"))
@@ -98,6 +176,7 @@ var (
 			HoldName,
 			MatterName,
 			CheckInputOnly,
+			Prefetch,
 		},
 		Before: func(c *cli.Context) error {
 			return checkTimezone(c.String("timezone"))
@@ -123,6 +202,38 @@ var (
 		},
 	}
 
+	SendCmd = &cli.Command{
+		Name: "send",
+		Subcommands: []*cli.Command{
+			SendNoticeCmd,
+			SendRemindersCmd,
+		},
+	}
+
+	SendNoticeCmd = &cli.Command{
+		Name:     "notice",
+		Category: "send",
+		Usage:    "send the hold notice of a legal hold to all, pending or selected custodians",
+		Action:   execute,
+		Flags:    DefaultSendNoticeOptions,
+	}
+
+	SendRemindersCmd = &cli.Command{
+		Name:     "reminders",
+		Category: "send",
+		Usage:    "send acknowledgement or hold reminders for a legal hold, or every legal hold of a matter or folder",
+		Action:   execute,
+		Flags:    DefaultSendRemindersOptions,
+	}
+
+	ReleaseCmd = &cli.Command{
+		Name:     "release",
+		Category: "release",
+		Usage:    "release all or selected custodians from a legal hold, a silent hold or every hold of a matter",
+		Action:   execute,
+		Flags:    DefaultReleaseOptions,
+	}
+
 	VerifyCmd = &cli.Command{
 		Name: "verify",
 		Subcommands: []*cli.Command{
@@ -205,6 +316,30 @@ var (
 		Flags:    DefaultListOptions,
 	}
 
+	GetStatsCmd = &cli.Command{
+		Name:     "stats",
+		Category: "get",
+		Usage:    "notice counts of a legal hold, of the holds of a matter or folder, or of every hold",
+		Action:   execute,
+		Flags:    DefaultStatsOptions,
+	}
+
+	GetHistoryCmd = &cli.Command{
+		Name:     "history",
+		Category: "get",
+		Usage:    "export the event history of a legal or silent hold",
+		Action:   execute,
+		Flags:    DefaultHistoryOptions,
+	}
+
+	GetNoticeCmd = &cli.Command{
+		Name:     "notice",
+		Category: "get",
+		Usage:    "get the hold notice of a legal hold, or the advisory or release notice of a silent hold",
+		Action:   execute,
+		Flags:    DefaultNoticeOptions,
+	}
+
 	CreateFolderCmd = &cli.Command{
 		Name:     "folder",
 		Category: "create",
@@ -224,31 +359,75 @@ var (
 		},
 	}
 
+	DeleteFolderCmd = &cli.Command{
+		Name:     "folder",
+		Category: "delete",
+		Action:   execute,
+		Flags:    DefaultDeleteOptions,
+	}
+
+	DeleteMatterCmd = &cli.Command{
+		Name:     "matter",
+		Category: "delete",
+		Action:   execute,
+		Flags:    DefaultDeleteOptions,
+	}
+
+	DeleteCustodianCmd = &cli.Command{
+		Name:     "custodian",
+		Category: "delete",
+		Action:   execute,
+		Flags:    DefaultDeleteOptions,
+	}
+
+	DeleteCustodianGroupCmd = &cli.Command{
+		Name:     "custodian_group",
+		Category: "delete",
+		Action:   execute,
+		Flags:    DefaultDeleteOptions,
+	}
+
+	DeleteQuestionnaireCmd = &cli.Command{
+		Name:     "questionnaire",
+		Category: "delete",
+		Action:   execute,
+		Flags:    DefaultDeleteOptions,
+	}
+
 	Commands = []*cli.Command{
+		APICmd,
+		AuditCmd,
+		ConfigCmd,
 		CreateCmd,
+		DeleteCmd,
 		GetCmd,
 		ImportCmd,
+		ReleaseCmd,
+		SendCmd,
 		VerifyCmd,
 	}
 )
 
-func loadConfig(file string) (*ClientConfig, error) {
-	data, err := os.ReadFile(file)
-	if err != nil {
-		return nil, err
-	}
-
-	var config ClientConfig
-	err = json.Unmarshal(data, &config)
-	if err != nil {
-		return nil, err
-	}
-
-	return &config, nil
-}
-
 func execute(ctx *cli.Context) error {
 	switch ctx.Command.Category {
+	case "api":
+		return apiCall(ctx)
+	case "audit":
+		switch ctx.Command.Name {
+		case "verify":
+			return auditVerify(ctx)
+		case "show":
+			return auditShow(ctx)
+		}
+	case "config":
+		switch ctx.Command.Name {
+		case "list":
+			return configList(ctx)
+		case "show":
+			return configShow(ctx)
+		case "use":
+			return configUse(ctx)
+		}
 	case "create":
 		switch ctx.Command.Name {
 		case "folder":
@@ -256,6 +435,19 @@ func execute(ctx *cli.Context) error {
 		case "matter":
 			return createMatter(ctx)
 		}
+	case "delete":
+		switch ctx.Command.Name {
+		case "folder":
+			return deleteFolder(ctx)
+		case "matter":
+			return deleteMatter(ctx)
+		case "custodian":
+			return deleteCustodian(ctx)
+		case "custodian_group":
+			return deleteCustodianGroup(ctx)
+		case "questionnaire":
+			return deleteQuestionnaire(ctx)
+		}
 	case "import":
 		switch ctx.Command.Name {
 		case "legalholds":
@@ -285,6 +477,21 @@ func execute(ctx *cli.Context) error {
 			return getGroups(ctx)
 		case "questionnaires":
 			return getQuestionnaires(ctx)
+		case "stats":
+			return getStats(ctx)
+		case "history":
+			return getHistory(ctx)
+		case "notice":
+			return getNotice(ctx)
+		}
+	case "release":
+		return release(ctx)
+	case "send":
+		switch ctx.Command.Name {
+		case "notice":
+			return sendNotice(ctx)
+		case "reminders":
+			return sendReminders(ctx)
 		}
 	case "verify":
 		switch ctx.Command.Name {
@@ -295,36 +502,86 @@ func execute(ctx *cli.Context) error {
 	return nil
 }
 
+// clients are the clients made by NewClient, closed by closeClients when the command is done.
+var clients []*otlh.Client
+
+// closeClients closes the clients of the command, which saves a --record cassette.
+func closeClients(ctx *cli.Context) error {
+	var errs []error
+	for _, client := range clients {
+		errs = append(errs, client.Close())
+	}
+	clients = nil
+	return errors.Join(errs...)
+}
+
 func NewClient(ctx *cli.Context) *otlh.Client {
-	var cfg *ClientConfig
-	var err error
+	cfg, profile, err := resolveClientConfig(ctx)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load config")
+	}
 
-	configPath := ctx.String("config")
-	if configPath != "" {
-		cfg, err = loadConfig(configPath)
-		if err != nil {
-			log.Fatal().Err(err).Msg("failed to load config")
-		}
+	tokenSource, err := cfg.tokenSource()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load config")
+	}
+
+	minTLSVersion, err := otlh.ParseTLSVersion(cfg.MinTLSVersion)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load config")
+	}
+
+	if cfg.SkipVerify {
+		log.Warn().Msg("TLS certificate verification is disabled")
 	}
 
-	if cfg == nil {
-		cfg = &ClientConfig{
-			Domain:    ctx.String("domain"),
-			Port:      ctx.Int("port"),
-			HttpProxy: ctx.String("httpProxy"),
-			Tenant:    ctx.String("tenant"),
-			AuthToken: ctx.String("authToken"),
+	retryPolicy := otlh.DefaultRetryPolicy()
+	retryPolicy.MaxAttempts = ctx.Int("maxAttempts")
+	retryPolicy.InitialBackoff = ctx.Duration("retryBackoff")
+	retryPolicy.MaxBackoff = ctx.Duration("retryMaxBackoff")
+	retryPolicy.RetryableStatusCodes = ctx.IntSlice("retryStatusCodes")
+
+	log.Debug().Msgf("using profile [%s] config: %+v", profile, cfg.masked())
+	b := otlh.NewClientBuilder()
+
+	if ctx.String("record") != "" {
+		b.WithRecorder(ctx.String("record"))
+	}
+
+	if ctx.String("replay") != "" {
+		b.WithReplayer(ctx.String("replay"))
+	}
+
+	if cfg.AuditJournal != "" {
+		journal, err := otlh.OpenAuditJournal(cfg.AuditJournal, operator(ctx))
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to open audit journal")
 		}
+		b.WithAuditJournal(journal)
 	}
 
-	log.Debug().Msgf("using config: %+v", cfg)
-	return otlh.NewClientBuilder().
+	client := b.
 		WithDomain(cfg.Domain).
 		WithPort(cfg.Port).
 		WithHttpProxy(cfg.HttpProxy).
 		WithTenant(cfg.Tenant).
-		WithAuthToken(cfg.AuthToken).
+		WithTokenSource(tokenSource).
+		WithCACertFile(cfg.CACertFile).
+		WithClientCertificate(cfg.ClientCertFile, cfg.ClientKeyFile).
+		WithMinTLSVersion(minTLSVersion).
+		WithSkipVerify(cfg.SkipVerify).
+		WithRetryPolicy(retryPolicy).
+		WithRateLimit(ctx.Float64("rateLimit"), ctx.Int("rateBurst")).
+		WithPageConcurrency(ctx.Int("pageConcurrency")).
+		WithRedactor(otlh.DefaultRedactor).
 		Build()
+
+	if err = client.Err(); err != nil {
+		log.Fatal().Err(err).Msg("failed to configure the client")
+	}
+
+	clients = append(clients, client)
+	return client
 }
 
 func importCustodians(ctx *cli.Context) error {
@@ -342,7 +599,7 @@ func importCustodians(ctx *cli.Context) error {
 
 	log.Debug().Msgf("data loaded")
 
-	if err = imp.Import(); err != nil {
+	if err = imp.ImportContext(ctx.Context); err != nil {
 		return err
 	}
 
@@ -358,7 +615,7 @@ func importLegalholds(ctx *cli.Context) error {
 	zip := ctx.String("zipfile")
 	if len(zip) > 0 {
 		if _, err := os.Stat(zip); err == nil || os.IsExist(err) {
-			if _, err = client.ImportLegalhold(zip); err != nil {
+			if _, err = client.ImportLegalholdContext(ctx.Context, zip); err != nil {
 				log.Error().Msgf("failed to import Legalhold from zip file: %s, %s", zip, err)
 				return err
 			}
@@ -375,6 +632,7 @@ func importLegalholds(ctx *cli.Context) error {
 		WithTimezone(tz).
 		WithMatterName(ctx.String("matterName")).
 		WithHoldName(ctx.String("holdName")).
+		WithPrefetch(ctx.Bool("prefetch")).
 		Legalhold(). // convert to legalholdExcelImporter type
 		WithAttachmentDirectory(ctx.String("attachmentDirectory"))
 
@@ -383,7 +641,7 @@ func importLegalholds(ctx *cli.Context) error {
 		return err
 	}
 
-	err = imp.PerformDataIntegrityCheck()
+	err = imp.PerformDataIntegrityCheckContext(ctx.Context)
 	if err != nil {
 		return err
 	}
@@ -392,7 +650,7 @@ func importLegalholds(ctx *cli.Context) error {
 		return nil
 	}
 
-	err = imp.Import()
+	err = imp.ImportContext(ctx.Context)
 	return err
 }
 
@@ -404,7 +662,7 @@ func importSilentholds(ctx *cli.Context) error {
 	zip := ctx.String("zipfile")
 	if len(zip) > 0 {
 		if _, err := os.Stat(zip); err == nil || os.IsExist(err) {
-			if _, err = client.ImportSilenthold(zip); err != nil {
+			if _, err = client.ImportSilentholdContext(ctx.Context, zip); err != nil {
 				log.Error().Msgf("failed to import Silenthold from zip file: %s, %s", zip, err)
 				return err
 			}
@@ -421,6 +679,7 @@ func importSilentholds(ctx *cli.Context) error {
 		WithTimezone(tz).
 		WithMatterName(ctx.String("matterName")).
 		WithHoldName(ctx.String("holdName")).
+		WithPrefetch(ctx.Bool("prefetch")).
 		Silenthold()
 
 	err = imp.LoadSilentholdData()
@@ -428,7 +687,7 @@ func importSilentholds(ctx *cli.Context) error {
 		return err
 	}
 
-	err = imp.PerformDataIntegrityCheck()
+	err = imp.PerformDataIntegrityCheckContext(ctx.Context)
 	if err != nil {
 		return err
 	}
@@ -437,7 +696,7 @@ func importSilentholds(ctx *cli.Context) error {
 		return nil
 	}
 
-	err = imp.Import()
+	err = imp.ImportContext(ctx.Context)
 	return err
 }
 
@@ -449,7 +708,7 @@ func ImportMatters(ctx *cli.Context) error {
 		WithExcel(ctx.String("excel")).
 		Build()
 
-	if err = imp.Import(); err != nil {
+	if err = imp.ImportContext(ctx.Context); err != nil {
 		return err
 	}
 
@@ -496,13 +755,12 @@ func getCustodians(ctx *cli.Context) error {
 
 	// Fetch custodians
 	if ctx.Int("id") > 0 {
-		v, err = client.GetCustodian(req)
+		v, err = client.GetCustodianContext(ctx.Context, req)
 	} else {
 		if ctx.Bool("all") {
-			v, err = client.GetAllCustodians(req, opts)
-		} else {
-			v, err = client.GetCustodians(req, opts)
+			return otlh.PrintSeq(otlh.NewPrinter().JSON().Build(), client.IterCustodiansContext(ctx.Context, req, opts))
 		}
+		v, err = client.GetCustodiansContext(ctx.Context, req, opts)
 	}
 
 	// Handle error and print result
@@ -534,13 +792,12 @@ func getCustodianGroups(ctx *cli.Context) error {
 	req, _ = b.Build()
 
 	if ctx.Int("id") > 0 {
-		v, err = client.GetCustodianGroup(req)
+		v, err = client.GetCustodianGroupContext(ctx.Context, req)
 	} else {
 		if ctx.Bool("all") {
-			v, err = client.GetAllCustodianGroups(req, opts)
-		} else {
-			v, err = client.GetCustodianGroups(req, opts)
+			return otlh.PrintSeq(otlh.NewPrinter().JSON().Build(), client.IterCustodianGroupsContext(ctx.Context, req, opts))
 		}
+		v, err = client.GetCustodianGroupsContext(ctx.Context, req, opts)
 	}
 
 	if err != nil {
@@ -565,7 +822,7 @@ func getFolders(ctx *cli.Context) error {
 
 	if ctx.Int("id") > 0 {
 		req, _ = b.WithID(ctx.Int("id")).Build()
-		v, err = client.GetFolder(req)
+		v, err = client.GetFolderContext(ctx.Context, req)
 	} else {
 		if ctx.Int("groupID") > 0 {
 			b.WithGroupID(ctx.Int("groupID"))
@@ -573,10 +830,9 @@ func getFolders(ctx *cli.Context) error {
 
 		req, _ = b.Build()
 		if ctx.Bool("all") {
-			v, err = client.GetAllFolders(req, opts)
-		} else {
-			v, err = client.GetFolders(req, opts)
+			return otlh.PrintSeq(otlh.NewPrinter().JSON().Build(), client.IterFoldersContext(ctx.Context, req, opts))
 		}
+		v, err = client.GetFoldersContext(ctx.Context, req, opts)
 	}
 
 	if err != nil {
@@ -600,14 +856,13 @@ func getGroups(ctx *cli.Context) error {
 	b := otlh.NewRequest().WithTenant(client.Tenant()).Get().Group()
 	if ctx.Int("id") > 0 {
 		req, _ = b.WithID(ctx.Int("id")).Build()
-		v, err = client.GetGroup(req)
+		v, err = client.GetGroupContext(ctx.Context, req)
 	} else {
 		req, _ = b.Build()
 		if ctx.Bool("all") {
-			v, err = client.GetAllGroups(req, opts)
-		} else {
-			v, err = client.GetGroups(req, opts)
+			return otlh.PrintSeq(otlh.NewPrinter().JSON().Build(), client.IterGroupsContext(ctx.Context, req, opts))
 		}
+		v, err = client.GetGroupsContext(ctx.Context, req, opts)
 	}
 
 	if err != nil {
@@ -631,14 +886,13 @@ func getMatters(ctx *cli.Context) error {
 	b := otlh.NewRequest().WithTenant(client.Tenant()).Get().Matter()
 	if ctx.Int("id") > 0 {
 		req, _ = b.WithID(ctx.Int("id")).Build()
-		v, err = client.GetMatter(req)
+		v, err = client.GetMatterContext(ctx.Context, req)
 	} else {
 		req, _ = b.Build()
 		if ctx.Bool("all") {
-			v, err = client.GetAllMatters(req, opts)
-		} else {
-			v, err = client.GetMatters(req, opts)
+			return otlh.PrintSeq(otlh.NewPrinter().JSON().Build(), client.IterMattersContext(ctx.Context, req, opts))
 		}
+		v, err = client.GetMattersContext(ctx.Context, req, opts)
 	}
 
 	if err != nil {
@@ -662,14 +916,13 @@ func getLegalholds(ctx *cli.Context) error {
 	b := otlh.NewRequest().WithTenant(client.Tenant()).Get().Legalhold()
 	if ctx.Int("id") > 0 {
 		req, _ = b.WithID(ctx.Int("id")).Build()
-		v, err = client.GetLegalhold(req)
+		v, err = client.GetLegalholdContext(ctx.Context, req)
 	} else {
 		req, _ = b.Build()
 		if ctx.Bool("all") {
-			v, err = client.GetAllLegalholds(req, opts)
-		} else {
-			v, err = client.GetLegalholds(req, opts)
+			return otlh.PrintSeq(otlh.NewPrinter().JSON().Build(), client.IterLegalholdsContext(ctx.Context, req, opts))
 		}
+		v, err = client.GetLegalholdsContext(ctx.Context, req, opts)
 	}
 
 	if err != nil {
@@ -693,14 +946,13 @@ func getSilentholds(ctx *cli.Context) error {
 	b := otlh.NewRequest().WithTenant(client.Tenant()).Get().Silenthold()
 	if ctx.Int("id") > 0 {
 		req, _ = b.WithID(ctx.Int("id")).Build()
-		v, err = client.GetSilenthold(req)
+		v, err = client.GetSilentholdContext(ctx.Context, req)
 	} else {
 		req, _ = b.Build()
 		if ctx.Bool("all") {
-			v, err = client.GetAllSilentholds(req, opts)
-		} else {
-			v, err = client.GetSilentholds(req, opts)
+			return otlh.PrintSeq(otlh.NewPrinter().JSON().Build(), client.IterSilentholdsContext(ctx.Context, req, opts))
 		}
+		v, err = client.GetSilentholdsContext(ctx.Context, req, opts)
 	}
 
 	if err != nil {
@@ -724,14 +976,13 @@ func getQuestionnaires(ctx *cli.Context) error {
 	b := otlh.NewRequest().WithTenant(client.Tenant()).Get().Questionnaire()
 	if ctx.Int("id") > 0 {
 		req, _ = b.WithID(ctx.Int("id")).Build()
-		v, err = client.GetQuestionnaire(req)
+		v, err = client.GetQuestionnaireContext(ctx.Context, req)
 	} else {
 		req, _ = b.Build()
 		if ctx.Bool("all") {
-			v, err = client.GetAllQuestionnaires(req, opts)
-		} else {
-			v, err = client.GetQuestionnaires(req, opts)
+			return otlh.PrintSeq(otlh.NewPrinter().JSON().Build(), client.IterQuestionnairesContext(ctx.Context, req, opts))
 		}
+		v, err = client.GetQuestionnairesContext(ctx.Context, req, opts)
 	}
 
 	if err != nil {
@@ -747,7 +998,7 @@ func createFolder(ctx *cli.Context) error {
 	var err error
 
 	client := NewClient(ctx)
-	_, err = client.FindOrCreateFolder(ctx.String("name"))
+	_, err = client.FindOrCreateFolderContext(ctx.Context, ctx.String("name"))
 
 	return err
 }
@@ -756,7 +1007,7 @@ func createMatter(ctx *cli.Context) error {
 	var err error
 
 	client := NewClient(ctx)
-	_, err = client.FindOrCreateMatter(ctx.String("name"), ctx.Int("folderID"))
+	_, err = client.FindOrCreateMatterContext(ctx.Context, ctx.String("name"), ctx.Int("folderID"))
 
 	return err
 }
@@ -792,3 +1043,125 @@ func verifyCustodians(ctx *cli.Context) error {
 
 	return nil
 }
+
+/*
+confirm asks the user to confirm action on stderr and reports whether the
+answer was yes. It is skipped, and true, with --yes.
+*/
+func confirm(ctx *cli.Context, action string) bool {
+	if ctx.Bool("yes") {
+		return true
+	}
+
+	fmt.Fprintf(os.Stderr, "%s? [y/N]: ", action)
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// deleteEntity deletes what after --dryRun and confirmation checks.
+func deleteEntity(ctx *cli.Context, what string, del func() error) error {
+	if ctx.Bool("dryRun") {
+		fmt.Printf("dry run: would delete %s\n", what)
+		return nil
+	}
+
+	if !confirm(ctx, "Delete "+what) {
+		fmt.Println("aborted")
+		return nil
+	}
+
+	if err := del(); err != nil {
+		return err
+	}
+
+	fmt.Printf("deleted %s\n", what)
+	return nil
+}
+
+func deleteFolder(ctx *cli.Context) error {
+	client := NewClient(ctx)
+
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Folder().WithID(ctx.Int("id")).Build()
+	folder, err := client.GetFolderContext(ctx.Context, req)
+	if err != nil {
+		return err
+	}
+
+	what := fmt.Sprintf("folder [%s] (id %d)", folder.Name, folder.ID)
+	if !folder.CanBeDeleted {
+		return fmt.Errorf("%s can not be deleted", what)
+	}
+
+	return deleteEntity(ctx, what, func() error {
+		return client.DeleteFolderContext(ctx.Context, folder.ID)
+	})
+}
+
+func deleteMatter(ctx *cli.Context) error {
+	client := NewClient(ctx)
+
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Matter().WithID(ctx.Int("id")).Build()
+	matter, err := client.GetMatterContext(ctx.Context, req)
+	if err != nil {
+		return err
+	}
+
+	what := fmt.Sprintf("matter [%s] (id %d)", matter.Name, matter.ID)
+	if !matter.CanBeDeleted {
+		return fmt.Errorf("%s can not be deleted", what)
+	}
+
+	return deleteEntity(ctx, what, func() error {
+		return client.DeleteMatterContext(ctx.Context, matter.ID)
+	})
+}
+
+func deleteCustodian(ctx *cli.Context) error {
+	client := NewClient(ctx)
+
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Custodian().WithID(ctx.Int("id")).Build()
+	custodian, err := client.GetCustodianContext(ctx.Context, req)
+	if err != nil {
+		return err
+	}
+
+	what := fmt.Sprintf("custodian [%s <%s>] (id %d)", custodian.Name, custodian.Email, custodian.ID)
+	return deleteEntity(ctx, what, func() error {
+		return client.DeleteCustodianContext(ctx.Context, custodian.ID)
+	})
+}
+
+func deleteCustodianGroup(ctx *cli.Context) error {
+	client := NewClient(ctx)
+
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().CustodianGroup().WithID(ctx.Int("id")).Build()
+	group, err := client.GetCustodianGroupContext(ctx.Context, req)
+	if err != nil {
+		return err
+	}
+
+	what := fmt.Sprintf("custodian group [%s] (id %d) with %d custodians", group.Name, group.ID, group.CustodiansCount)
+	return deleteEntity(ctx, what, func() error {
+		return client.DeleteCustodianGroupContext(ctx.Context, group.ID)
+	})
+}
+
+func deleteQuestionnaire(ctx *cli.Context) error {
+	client := NewClient(ctx)
+
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Questionnaire().WithID(ctx.Int("id")).Build()
+	questionnaire, err := client.GetQuestionnaireContext(ctx.Context, req)
+	if err != nil {
+		return err
+	}
+
+	what := fmt.Sprintf("questionnaire [%s] (id %d)", questionnaire.Name, questionnaire.ID)
+	return deleteEntity(ctx, what, func() error {
+		return client.DeleteQuestionnaireContext(ctx.Context, questionnaire.ID)
+	})
+}
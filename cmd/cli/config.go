@@ -0,0 +1,365 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	otlh "github.com/xifanyan/otlh/pkg"
+)
+
+const DEFAULT_PROFILE = "default"
+
+/*
+ClientConfig holds the connection settings of one profile. Empty fields fall
+back to the global flag defaults.
+*/
+type ClientConfig struct {
+	Domain    string `json:"domain,omitempty"`
+	Port      int    `json:"port,omitempty"`
+	HttpProxy string `json:"httpProxy,omitempty"`
+	Tenant    string `json:"tenant,omitempty"`
+	AuthToken string `json:"authToken,omitempty"`
+
+	// AuthTokenFile and AuthTokenCommand replace AuthToken, see --authTokenFile and --authTokenCommand.
+	AuthTokenFile    string `json:"authTokenFile,omitempty"`
+	AuthTokenCommand string `json:"authTokenCommand,omitempty"`
+	AuthTokenTTL     string `json:"authTokenTTL,omitempty"`
+
+	CACertFile     string `json:"caCertFile,omitempty"`
+	ClientCertFile string `json:"clientCertFile,omitempty"`
+	ClientKeyFile  string `json:"clientKeyFile,omitempty"`
+	MinTLSVersion  string `json:"minTLSVersion,omitempty"`
+	SkipVerify     bool   `json:"skipVerify,omitempty"`
+
+	AuditJournal string `json:"auditJournal,omitempty"`
+}
+
+/*
+ConfigFile is the content of the config file: named profiles and the one
+used when --profile is not given.
+
+	{
+	    "current": "sandbox",
+	    "profiles": {
+	        "sandbox": {"tenant": "acme_sandbox", "authToken": "..."},
+	        "prod": {"tenant": "acme", "authTokenCommand": "vault read -field=token secret/otlh"}
+	    }
+	}
+
+A file holding a single ClientConfig, the format used before profiles were
+introduced, is read as a profile named "default".
+*/
+type ConfigFile struct {
+	Current  string                   `json:"current,omitempty"`
+	Profiles map[string]*ClientConfig `json:"profiles"`
+}
+
+// defaultConfigPath returns <user config dir>/otlh/config.json.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "otlh", "config.json")
+}
+
+/*
+configPath returns the config file given with --config, or the default one.
+The default file is optional, so it is only returned when it exists or when
+mustExist is false.
+*/
+func configPath(ctx *cli.Context, mustExist bool) string {
+	if path := ctx.String("config"); path != "" {
+		return path
+	}
+
+	path := defaultConfigPath()
+	if path == "" || !mustExist {
+		return path
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+func loadConfig(file string) (*ConfigFile, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var config ConfigFile
+	if err = json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", file, err)
+	}
+
+	if config.Profiles == nil {
+		var legacy ClientConfig
+		if err = json.Unmarshal(data, &legacy); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", file, err)
+		}
+
+		config = ConfigFile{
+			Current:  DEFAULT_PROFILE,
+			Profiles: map[string]*ClientConfig{DEFAULT_PROFILE: &legacy},
+		}
+	}
+
+	return &config, nil
+}
+
+func (c *ConfigFile) save(file string) error {
+	data, err := json.MarshalIndent(c, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+
+	// the file holds auth tokens
+	return os.WriteFile(file, data, 0600)
+}
+
+// profileNames returns the profile names in alphabetical order.
+func (c *ConfigFile) profileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+/*
+activeProfile returns the name of the profile to use: name when given, else
+the current profile of the file, else the only profile in it.
+*/
+func (c *ConfigFile) activeProfile(name string) (string, error) {
+	if name == "" {
+		name = c.Current
+	}
+
+	if name == "" && len(c.Profiles) == 1 {
+		for only := range c.Profiles {
+			name = only
+		}
+	}
+
+	if name == "" {
+		return "", nil
+	}
+
+	if _, ok := c.Profiles[name]; !ok {
+		return "", fmt.Errorf("profile %s not found, available profiles: %v", name, c.profileNames())
+	}
+
+	return name, nil
+}
+
+/*
+resolveClientConfig merges the connection settings with the precedence
+flag > environment variable > profile > flag default. urfave/cli already
+prefers a flag over its environment variable, so a setting counts as given
+when ctx.IsSet reports it. It returns the name of the profile used, if any.
+*/
+func resolveClientConfig(ctx *cli.Context) (*ClientConfig, string, error) {
+	var cfg ClientConfig
+	var profile string
+
+	path := configPath(ctx, true)
+	if path != "" {
+		file, err := loadConfig(path)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if profile, err = file.activeProfile(ctx.String("profile")); err != nil {
+			return nil, "", err
+		}
+
+		if profile != "" {
+			cfg = *file.Profiles[profile]
+		}
+	} else if ctx.String("profile") != "" {
+		return nil, "", fmt.Errorf("profile %s requested but no config file found", ctx.String("profile"))
+	}
+
+	if ctx.IsSet("domain") || cfg.Domain == "" {
+		cfg.Domain = ctx.String("domain")
+	}
+	if ctx.IsSet("port") || cfg.Port == 0 {
+		cfg.Port = ctx.Int("port")
+	}
+	if ctx.IsSet("proxy") || cfg.HttpProxy == "" {
+		cfg.HttpProxy = ctx.String("proxy")
+	}
+	if ctx.IsSet("tenant") || cfg.Tenant == "" {
+		cfg.Tenant = ctx.String("tenant")
+	}
+	if ctx.IsSet("caCertFile") || cfg.CACertFile == "" {
+		cfg.CACertFile = ctx.String("caCertFile")
+	}
+	if ctx.IsSet("clientCertFile") || ctx.IsSet("clientKeyFile") || cfg.ClientCertFile == "" {
+		cfg.ClientCertFile = ctx.String("clientCertFile")
+		cfg.ClientKeyFile = ctx.String("clientKeyFile")
+	}
+	if ctx.IsSet("minTLSVersion") || cfg.MinTLSVersion == "" {
+		cfg.MinTLSVersion = ctx.String("minTLSVersion")
+	}
+	if ctx.IsSet("skipVerify") || !cfg.SkipVerify {
+		cfg.SkipVerify = ctx.Bool("skipVerify")
+	}
+	if ctx.IsSet("auditJournal") || cfg.AuditJournal == "" {
+		cfg.AuditJournal = ctx.String("auditJournal")
+	}
+
+	// a token given on the command line replaces however the profile gets its token
+	if ctx.IsSet("authToken") || ctx.IsSet("authTokenFile") || ctx.IsSet("authTokenCommand") {
+		cfg.AuthToken = ctx.String("authToken")
+		cfg.AuthTokenFile = ctx.String("authTokenFile")
+		cfg.AuthTokenCommand = ctx.String("authTokenCommand")
+	}
+	if ctx.IsSet("authTokenTTL") || cfg.AuthTokenTTL == "" {
+		cfg.AuthTokenTTL = ctx.Duration("authTokenTTL").String()
+	}
+
+	set := 0
+	for _, v := range []string{cfg.AuthToken, cfg.AuthTokenFile, cfg.AuthTokenCommand} {
+		if v != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return nil, "", fmt.Errorf("only one of authToken, authTokenFile and authTokenCommand can be used")
+	}
+
+	return &cfg, profile, nil
+}
+
+// tokenSource returns where the client gets its auth token from.
+func (c *ClientConfig) tokenSource() (otlh.TokenSource, error) {
+	switch {
+	case c.AuthTokenCommand != "":
+		ttl, err := time.ParseDuration(c.AuthTokenTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid authTokenTTL %s: %w", c.AuthTokenTTL, err)
+		}
+		return otlh.NewCommandTokenSource(c.AuthTokenCommand, ttl), nil
+	case c.AuthTokenFile != "":
+		return otlh.NewFileTokenSource(c.AuthTokenFile), nil
+	}
+	return otlh.StaticToken(c.AuthToken), nil
+}
+
+// maskToken hides all but the last 4 characters of a token.
+func maskToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	if len(token) <= 8 {
+		return "****"
+	}
+	return "****" + token[len(token)-4:]
+}
+
+// masked returns a copy of c safe to print.
+func (c ClientConfig) masked() ClientConfig {
+	c.AuthToken = maskToken(c.AuthToken)
+	c.HttpProxy = otlh.DefaultRedactor.String(c.HttpProxy)
+	return c
+}
+
+func configList(ctx *cli.Context) error {
+	path := configPath(ctx, true)
+	if path == "" {
+		return fmt.Errorf("no config file found, expected %s", defaultConfigPath())
+	}
+
+	file, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("config file: %s\n", path)
+	for _, name := range file.profileNames() {
+		marker := " "
+		if name == file.Current {
+			marker = "*"
+		}
+
+		p := file.Profiles[name]
+		fmt.Printf("%s %-20s tenant: %-20s domain: %s\n", marker, name, p.Tenant, p.Domain)
+	}
+
+	return nil
+}
+
+/*
+configShow prints the settings resolved for the profile named in the first
+argument, or the active one, with the auth token masked.
+*/
+func configShow(ctx *cli.Context) error {
+	if name := ctx.Args().First(); name != "" {
+		if err := ctx.Set("profile", name); err != nil {
+			return err
+		}
+	}
+
+	cfg, profile, err := resolveClientConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	v := struct {
+		Profile string `json:"profile,omitempty"`
+		ClientConfig
+	}{profile, cfg.masked()}
+
+	data, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(data))
+	return nil
+}
+
+// configUse makes the profile named in the first argument the current one.
+func configUse(ctx *cli.Context) error {
+	name := ctx.Args().First()
+	if name == "" {
+		return fmt.Errorf("profile name is required")
+	}
+
+	path := configPath(ctx, false)
+	file, err := loadConfig(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("config file %s not found", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, ok := file.Profiles[name]; !ok {
+		return fmt.Errorf("profile %s not found, available profiles: %v", name, file.profileNames())
+	}
+
+	file.Current = name
+	if err = file.save(path); err != nil {
+		return err
+	}
+
+	fmt.Printf("using profile %s\n", name)
+	return nil
+}
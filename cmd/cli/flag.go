@@ -145,6 +145,144 @@ var (
 		Value:   "",
 	}
 
+	Prefetch = &cli.BoolFlag{
+		Name:    "prefetch",
+		Aliases: []string{"pf"},
+		Usage:   "load all custodians, folders and matters once instead of looking them up one by one",
+	}
+
+	DeleteID = &cli.IntFlag{
+		Name:     "id",
+		Usage:    "id of the entity to delete",
+		Required: true,
+	}
+
+	DryRun = &cli.BoolFlag{
+		Name:  "dryRun",
+		Usage: "show what would be done without changing anything",
+	}
+
+	Yes = &cli.BoolFlag{
+		Name:  "yes",
+		Usage: "do not ask for confirmation",
+	}
+
+	BodyInput = &cli.StringFlag{
+		Name:    "input",
+		Aliases: []string{"i"},
+		Usage:   "file holding the JSON request body, - reads it from stdin",
+	}
+
+	Paginate = &cli.BoolFlag{
+		Name:  "paginate",
+		Usage: "fetch all pages of a list and merge their _embedded arrays",
+	}
+
+	AllHolds = &cli.BoolFlag{
+		Name:  "all",
+		Usage: "every legal hold",
+	}
+
+	Output = &cli.StringFlag{
+		Name:    "output",
+		Aliases: []string{"o"},
+		Usage:   "output format, json or table",
+		Value:   "json",
+	}
+
+	HistoryOutput = &cli.StringFlag{
+		Name:    "output",
+		Aliases: []string{"o"},
+		Usage:   "output format, json, csv or xlsx",
+		Value:   "json",
+	}
+
+	OutputFile = &cli.StringFlag{
+		Name:    "outputFile",
+		Aliases: []string{"of"},
+		Usage:   "file to write the output to instead of stdout, required for xlsx",
+	}
+
+	Email = &cli.StringFlag{
+		Name:  "email",
+		Usage: "only events of the custodian with this email",
+	}
+
+	Since = &cli.StringFlag{
+		Name:  "since",
+		Usage: "only events on or after this date, 2006-01-02 (UTC) or 2006-01-02T15:04:05Z",
+	}
+
+	Until = &cli.StringFlag{
+		Name:  "until",
+		Usage: "only events on or before this date, 2006-01-02 (UTC, the whole day) or 2006-01-02T15:04:05Z",
+	}
+
+	NoticeType = &cli.StringFlag{
+		Name:  "type",
+		Usage: "notice of a silent hold: advisory (default), release or advisory_copies; legal holds only have the hold notice",
+	}
+
+	OutputDir = &cli.StringFlag{
+		Name:    "outputDir",
+		Aliases: []string{"od"},
+		Usage:   "directory to save each notice to as .html, .txt and .json, instead of printing it",
+	}
+
+	DownloadAttachments = &cli.StringFlag{
+		Name:    "downloadAttachments",
+		Aliases: []string{"da"},
+		Usage:   "directory to download the attachments of the notice into",
+	}
+
+	NoticeLegalHoldID = &cli.IntFlag{
+		Name:     "legalHoldID",
+		Usage:    "legalhold id",
+		Required: true,
+	}
+
+	PendingOnly = &cli.BoolFlag{
+		Name:  "pendingOnly",
+		Usage: "only custodians that have neither acknowledged the notice nor been released since they were last added or sent it",
+	}
+
+	CustodianIDs = &cli.IntSliceFlag{
+		Name:  "custodianIDs",
+		Usage: "only the custodians with these ids, e.g. --custodianIDs 1001,1002",
+	}
+
+	EmailFile = &cli.StringFlag{
+		Name:  "emailFile",
+		Usage: "only the custodians with the emails in this file, one per line or a csv file with an email column",
+	}
+
+	ReminderType = &cli.StringFlag{
+		Name:     "type",
+		Usage:    "reminders to send: acknowledgement or hold",
+		Required: true,
+	}
+
+	SendReleaseNotice = &cli.BoolFlag{
+		Name:  "sendReleaseNotice",
+		Usage: "send the release notice of the hold to the released custodians",
+	}
+
+	ReleaseOutputFile = &cli.StringFlag{
+		Name:    "outputFile",
+		Aliases: []string{"of"},
+		Usage:   "file to write the per-custodian report to as csv instead of printing it",
+	}
+
+	HoldID = &cli.IntFlag{
+		Name:  "holdID",
+		Usage: "legalhold or silenthold id",
+	}
+
+	Anchor = &cli.StringSliceFlag{
+		Name:  "anchor",
+		Usage: "head seq:hash printed by an earlier verify that the journal must still contain, can be repeated",
+	}
+
 	BatchSize = &cli.IntFlag{
 		Name:    "batchSize",
 		Aliases: []string{"bs"},
@@ -153,6 +291,83 @@ var (
 	}
 )
 
+var DefaultDeleteOptions = []cli.Flag{
+	DeleteID,
+	DryRun,
+	Yes,
+}
+
+var DefaultAPIOptions = []cli.Flag{
+	BodyInput,
+	Paginate,
+	PageSize,
+}
+
+var DefaultAuditVerifyOptions = []cli.Flag{
+	Anchor,
+}
+
+var DefaultAuditShowOptions = []cli.Flag{
+	MatterID,
+	HoldID,
+}
+
+var DefaultStatsOptions = []cli.Flag{
+	LegalHoldID,
+	MatterID,
+	FolderID,
+	AllHolds,
+	Output,
+}
+
+var DefaultHistoryOptions = []cli.Flag{
+	LegalHoldID,
+	SilentHoldID,
+	Email,
+	Since,
+	Until,
+	HistoryOutput,
+	OutputFile,
+}
+
+var DefaultNoticeOptions = []cli.Flag{
+	LegalHoldID,
+	SilentHoldID,
+	NoticeType,
+	OutputDir,
+	DownloadAttachments,
+}
+
+var DefaultSendNoticeOptions = []cli.Flag{
+	NoticeLegalHoldID,
+	PendingOnly,
+	CustodianIDs,
+	EmailFile,
+	DryRun,
+	Yes,
+}
+
+var DefaultSendRemindersOptions = []cli.Flag{
+	ReminderType,
+	LegalHoldID,
+	MatterID,
+	FolderID,
+	DryRun,
+	Yes,
+}
+
+var DefaultReleaseOptions = []cli.Flag{
+	LegalHoldID,
+	SilentHoldID,
+	MatterID,
+	CustodianIDs,
+	EmailFile,
+	SendReleaseNotice,
+	ReleaseOutputFile,
+	DryRun,
+	Yes,
+}
+
 var DefaultListOptions = []cli.Flag{
 	All,
 	ID,
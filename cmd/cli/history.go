@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xuri/excelize/v2"
+)
+
+const SHEET_NAME_HISTORY = "History"
+
+var HistoryHeader = []string{"ID", "Time", "Event", "Custodian Name", "Custodian Email", "Description", "Performed By"}
+
+func historyRow(e otlh.HistoryEvent) []string {
+	return []string{
+		strconv.Itoa(e.ID),
+		e.CreatedAt,
+		e.Event,
+		e.Custodian.Name,
+		e.Custodian.Email,
+		e.Description,
+		e.PerformedBy.Name,
+	}
+}
+
+/*
+parseDate parses a --since/--until value, a date (2006-01-02, UTC) or an RFC
+3339 time. With endOfDay a date stands for its last instant, so that --until
+includes the whole day.
+*/
+func parseDate(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		if endOfDay {
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return t, fmt.Errorf("invalid date %s, use 2006-01-02 or 2006-01-02T15:04:05Z", value)
+	}
+	return t, nil
+}
+
+func getHistory(ctx *cli.Context) error {
+	legalholdID := ctx.Int("legalHoldID")
+	silentholdID := ctx.Int("silentHoldID")
+	if (legalholdID > 0) == (silentholdID > 0) {
+		return fmt.Errorf("pass one of --legalHoldID or --silentHoldID")
+	}
+
+	output := ctx.String("output")
+	switch output {
+	case "json", "csv":
+	case "xlsx":
+		if ctx.String("outputFile") == "" {
+			return fmt.Errorf("--output xlsx needs --outputFile")
+		}
+	default:
+		return fmt.Errorf("unsupported output %s, use json, csv or xlsx", output)
+	}
+
+	var filter otlh.HistoryFilter
+	var err error
+
+	filter.Email = ctx.String("email")
+	if filter.Since, err = parseDate(ctx.String("since"), false); err != nil {
+		return err
+	}
+	if filter.Until, err = parseDate(ctx.String("until"), true); err != nil {
+		return err
+	}
+
+	client := NewClient(ctx)
+
+	var events otlh.HistoryEvents
+	if legalholdID > 0 {
+		req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Legalhold().WithID(legalholdID).Build()
+		legalhold, err := client.GetLegalholdContext(ctx.Context, req)
+		if err != nil {
+			return err
+		}
+		events, err = client.GetLegalholdHistoryContext(ctx.Context, legalhold)
+		if err != nil {
+			return err
+		}
+	} else {
+		req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Silenthold().WithID(silentholdID).Build()
+		silenthold, err := client.GetSilentholdContext(ctx.Context, req)
+		if err != nil {
+			return err
+		}
+		events, err = client.GetSilentholdHistoryContext(ctx.Context, silenthold)
+		if err != nil {
+			return err
+		}
+	}
+
+	events = events.Filter(filter)
+
+	if output == "xlsx" {
+		return writeHistoryExcel(ctx.String("outputFile"), events)
+	}
+
+	var w io.Writer = os.Stdout
+	if path := ctx.String("outputFile"); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if output == "csv" {
+		return writeHistoryCSV(w, events)
+	}
+
+	b, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
+
+func writeHistoryCSV(w io.Writer, events otlh.HistoryEvents) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(HistoryHeader); err != nil {
+		return err
+	}
+	for _, e := range events {
+		if err := cw.Write(historyRow(e)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeHistoryExcel(path string, events otlh.HistoryEvents) error {
+	var err error
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err = f.SetSheetName("Sheet1", SHEET_NAME_HISTORY); err != nil {
+		return err
+	}
+
+	if err = f.SetSheetRow(SHEET_NAME_HISTORY, "A1", &HistoryHeader); err != nil {
+		return err
+	}
+	for i, e := range events {
+		row := historyRow(e)
+		if err = f.SetSheetRow(SHEET_NAME_HISTORY, fmt.Sprintf("A%d", i+2), &row); err != nil {
+			return err
+		}
+	}
+
+	return f.SaveAs(path)
+}
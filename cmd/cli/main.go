@@ -1,7 +1,11 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"os/signal"
 
 	otlh "github.com/xifanyan/otlh/pkg"
 
@@ -37,6 +41,35 @@ func main() {
 				EnvVars: []string{"LHN_HTTPPROXY"},
 				Value:   "",
 			},
+			&cli.StringFlag{
+				Name:    "caCertFile",
+				Usage:   "PEM file with CA certificates trusted in addition to the system ones, e.g. of a TLS intercepting proxy",
+				EnvVars: []string{"LHN_CACERTFILE"},
+				Value:   "",
+			},
+			&cli.StringFlag{
+				Name:    "clientCertFile",
+				Usage:   "PEM client certificate for mutual TLS, requires --clientKeyFile",
+				EnvVars: []string{"LHN_CLIENTCERTFILE"},
+				Value:   "",
+			},
+			&cli.StringFlag{
+				Name:    "clientKeyFile",
+				Usage:   "PEM private key of --clientCertFile",
+				EnvVars: []string{"LHN_CLIENTKEYFILE"},
+				Value:   "",
+			},
+			&cli.StringFlag{
+				Name:    "minTLSVersion",
+				Usage:   "lowest TLS version accepted: 1.0, 1.1, 1.2 or 1.3",
+				EnvVars: []string{"LHN_MINTLSVERSION"},
+				Value:   "1.2",
+			},
+			&cli.BoolFlag{
+				Name:  "skipVerify",
+				Usage: "do not verify the service's TLS certificate, insecure, prefer --caCertFile",
+				Value: false,
+			},
 			&cli.StringFlag{
 				Name:    "tenant",
 				Aliases: []string{"t"},
@@ -51,13 +84,94 @@ func main() {
 				EnvVars: []string{"LHN_AUTHTOKEN"},
 				Value:   "",
 			},
+			&cli.StringFlag{
+				Name:    "authTokenFile",
+				Usage:   "file holding the auth token, must not be readable by other users",
+				EnvVars: []string{"LHN_AUTHTOKENFILE"},
+				Value:   "",
+			},
+			&cli.StringFlag{
+				Name:    "authTokenCommand",
+				Usage:   "command printing the auth token, e.g. a vault CLI call",
+				EnvVars: []string{"LHN_AUTHTOKENCOMMAND"},
+				Value:   "",
+			},
+			&cli.DurationFlag{
+				Name:    "authTokenTTL",
+				Usage:   "how long the output of --authTokenCommand is reused, 0 until the service rejects it",
+				EnvVars: []string{"LHN_AUTHTOKENTTL"},
+				Value:   otlh.DEFAULT_TOKEN_TTL,
+			},
 			&cli.StringFlag{
 				Name:    "config",
 				Aliases: []string{"c"},
-				Usage:   "LHN json config file",
+				Usage:   "LHN json config file, defaults to otlh/config.json in the user config directory",
 				EnvVars: []string{"LHN_CONFIG"},
 				Value:   "",
 			},
+			&cli.StringFlag{
+				Name:    "profile",
+				Usage:   "profile of the config file to use",
+				EnvVars: []string{"LHN_PROFILE"},
+				Value:   "",
+			},
+			&cli.IntFlag{
+				Name:  "maxAttempts",
+				Usage: "maximum number of attempts per request, 1 disables retries",
+				Value: otlh.DEFAULT_MAX_ATTEMPTS,
+			},
+			&cli.DurationFlag{
+				Name:  "retryBackoff",
+				Usage: "initial wait between retries, doubled on each attempt",
+				Value: otlh.DEFAULT_INITIAL_BACKOFF,
+			},
+			&cli.DurationFlag{
+				Name:  "retryMaxBackoff",
+				Usage: "maximum wait between retries",
+				Value: otlh.DEFAULT_MAX_BACKOFF,
+			},
+			&cli.IntSliceFlag{
+				Name:  "retryStatusCodes",
+				Usage: "http status codes that are retried for idempotent requests",
+				Value: cli.NewIntSlice(otlh.DefaultRetryableStatusCodes...),
+			},
+			&cli.Float64Flag{
+				Name:    "rateLimit",
+				Usage:   "maximum requests per second sent to the service, 0 means no maximum. Requests slow down on 429 responses either way",
+				EnvVars: []string{"LHN_RATELIMIT"},
+				Value:   0,
+			},
+			&cli.IntFlag{
+				Name:  "rateBurst",
+				Usage: "number of requests allowed to exceed the rate limit in a burst",
+				Value: 1,
+			},
+			&cli.IntFlag{
+				Name:    "pageConcurrency",
+				Usage:   "number of pages fetched at once when getting all entities",
+				EnvVars: []string{"LHN_PAGECONCURRENCY"},
+				Value:   otlh.DEFAULT_PAGE_CONCURRENCY,
+			},
+			&cli.StringFlag{
+				Name:    "auditJournal",
+				Usage:   "append every change made to the service to this hash-chained JSONL journal",
+				EnvVars: []string{"LHN_AUDITJOURNAL"},
+				Value:   "",
+			},
+			&cli.StringFlag{
+				Name:    "operator",
+				Usage:   "operator recorded in the audit journal, defaults to user@host",
+				EnvVars: []string{"LHN_OPERATOR"},
+				Value:   "",
+			},
+			&cli.StringFlag{
+				Name:  "record",
+				Usage: "record the session to a cassette file, tokens are redacted",
+			},
+			&cli.StringFlag{
+				Name:  "replay",
+				Usage: "replay the session from a cassette file instead of calling the service",
+			},
 			&cli.BoolFlag{
 				Name:    "debug",
 				Aliases: []string{"d"},
@@ -70,9 +184,19 @@ func main() {
 				Usage:   "Trace Mode",
 				Value:   false,
 			},
+			&cli.BoolFlag{
+				Name:    "pii",
+				Usage:   "mask custodian emails and names in the log output",
+				EnvVars: []string{"LHN_PII"},
+				Value:   false,
+			},
 		},
 		Commands: Commands,
 		Before: func(c *cli.Context) error {
+			if c.String("record") != "" && c.String("replay") != "" {
+				return fmt.Errorf("--record and --replay are mutually exclusive")
+			}
+
 			zerolog.SetGlobalLevel(zerolog.InfoLevel)
 			if c.Bool("debug") {
 				zerolog.SetGlobalLevel(zerolog.DebugLevel)
@@ -80,14 +204,49 @@ func main() {
 			if c.Bool("trace") {
 				zerolog.SetGlobalLevel(zerolog.TraceLevel)
 			}
-			log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+			otlh.DefaultRedactor.SetPII(c.Bool("pii"))
+			log.Logger = log.Output(zerolog.ConsoleWriter{Out: otlh.DefaultRedactor.Writer(os.Stderr)})
 			return nil
 		},
+		After: closeClients,
 	}
 
-	err := app.Run(os.Args)
+	// cancel in-flight requests on Ctrl-C, a second Ctrl-C kills the process
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	context.AfterFunc(ctx, stop)
+
+	err := app.RunContext(ctx, os.Args)
+	stop()
 	if err != nil {
-		log.Error().Msgf("error: %s", err)
+		logError(err)
 		os.Exit(1)
 	}
 }
+
+// logError prints err, expanding the server response for API errors.
+func logError(err error) {
+	if errors.Is(err, context.Canceled) {
+		log.Error().Msg("error: interrupted")
+		return
+	}
+
+	var apiErr *otlh.APIError
+	if !errors.As(err, &apiErr) {
+		log.Error().Msgf("error: %s", err)
+		return
+	}
+
+	log.Error().Msgf("error: %s %s failed with status code %d", apiErr.Method, apiErr.Endpoint, apiErr.StatusCode)
+	if apiErr.RequestID != "" {
+		log.Error().Msgf("request id: %s", apiErr.RequestID)
+	}
+
+	messages := apiErr.Messages()
+	for _, msg := range messages {
+		log.Error().Msgf(" - %s", msg)
+	}
+
+	if len(messages) == 0 && len(apiErr.Body) > 0 {
+		log.Error().Msgf("response: %s", apiErr.Body)
+	}
+}
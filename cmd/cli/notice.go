@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+	otlh "github.com/xifanyan/otlh/pkg"
+)
+
+// noticeFile is a notice to print or save: the notice, its body as text and the base name of its files.
+type noticeFile struct {
+	otlh.Notice
+	Text      string `json:"text"`
+	SentAt    string `json:"sent_at,omitempty"`
+	Recipient string `json:"recipient,omitempty"`
+	name      string
+}
+
+// fetchNotices gets the notice of --type of the hold selected by --legalHoldID or --silentHoldID.
+func fetchNotices(ctx *cli.Context, client *otlh.Client) ([]noticeFile, error) {
+	legalholdID := ctx.Int("legalHoldID")
+	silentholdID := ctx.Int("silentHoldID")
+	if (legalholdID > 0) == (silentholdID > 0) {
+		return nil, fmt.Errorf("pass one of --legalHoldID or --silentHoldID")
+	}
+
+	noticeType := ctx.String("type")
+
+	if legalholdID > 0 {
+		if noticeType != "" && noticeType != "hold" {
+			return nil, fmt.Errorf("legal holds only have a hold notice, not %s", noticeType)
+		}
+
+		req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Legalhold().WithID(legalholdID).Build()
+		legalhold, err := client.GetLegalholdContext(ctx.Context, req)
+		if err != nil {
+			return nil, err
+		}
+
+		notice, err := client.GetLegalholdNoticeContext(ctx.Context, legalhold)
+		if err != nil {
+			return nil, err
+		}
+		return []noticeFile{{Notice: notice, Text: notice.PlainText(), name: fmt.Sprintf("legal_hold_%d_hold_notice", legalhold.ID)}}, nil
+	}
+
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Silenthold().WithID(silentholdID).Build()
+	silenthold, err := client.GetSilentholdContext(ctx.Context, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var notice otlh.Notice
+	switch noticeType {
+	case "", "advisory":
+		notice, err = client.GetSilentholdAdvisoryNoticeContext(ctx.Context, silenthold)
+		noticeType = "advisory"
+	case "release":
+		notice, err = client.GetSilentholdReleaseNoticeContext(ctx.Context, silenthold)
+	case "advisory_copies":
+		copies, err := client.GetSilentholdAdvisoryCopiesContext(ctx.Context, silenthold)
+		if err != nil {
+			return nil, err
+		}
+
+		files := []noticeFile{}
+		for _, c := range copies {
+			files = append(files, noticeFile{
+				Notice:    c.Notice,
+				Text:      c.PlainText(),
+				SentAt:    c.SentAt,
+				Recipient: c.Recipient.Email,
+				name:      fmt.Sprintf("silent_hold_%d_advisory_copy_%d", silenthold.ID, c.ID),
+			})
+		}
+		return files, nil
+	default:
+		return nil, fmt.Errorf("unsupported notice type %s for a silent hold, use advisory, release or advisory_copies", noticeType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []noticeFile{{Notice: notice, Text: notice.PlainText(), name: fmt.Sprintf("silent_hold_%d_%s_notice", silenthold.ID, noticeType)}}, nil
+}
+
+/*
+saveNotice writes the body of a notice to <name>.html and <name>.txt, and the
+whole notice, subject, title and attachment list included, to <name>.json in
+dir.
+*/
+func saveNotice(dir string, n noticeFile) error {
+	if err := os.WriteFile(filepath.Join(dir, n.name+".html"), []byte(n.Body), 0644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, n.name+".txt"), []byte(n.Text), 0644); err != nil {
+		return err
+	}
+
+	// keep the HTML of the body readable instead of \u003c escapes
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(n); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, n.name+".json"), buf.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("saved %s to %s", n.name, dir)
+	return nil
+}
+
+func getNotice(ctx *cli.Context) error {
+	client := NewClient(ctx)
+
+	notices, err := fetchNotices(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	outputDir := ctx.String("outputDir")
+	attachmentDir := ctx.String("downloadAttachments")
+
+	for _, dir := range []string{outputDir, attachmentDir} {
+		if dir == "" {
+			continue
+		}
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	if outputDir == "" {
+		otlh.NewPrinter().JSON().Build().Print(notices)
+	}
+
+	for _, n := range notices {
+		if outputDir != "" {
+			if err = saveNotice(outputDir, n); err != nil {
+				return err
+			}
+		}
+
+		if attachmentDir == "" {
+			continue
+		}
+		for _, attachment := range n.Attachments {
+			path, err := client.DownloadNoticeAttachmentContext(ctx.Context, attachment, attachmentDir)
+			if err != nil {
+				return err
+			}
+			log.Info().Msgf("downloaded attachment %s", path)
+		}
+	}
+
+	return nil
+}
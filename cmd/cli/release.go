@@ -0,0 +1,371 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+	otlh "github.com/xifanyan/otlh/pkg"
+)
+
+// releaseHold is a legal or silent hold to release custodians from, with its custodians and history.
+type releaseHold struct {
+	kind       string
+	id         int
+	name       string
+	custodians otlh.Custodians
+	history    otlh.HistoryEvents
+}
+
+// releaseResult is the outcome of releasing one custodian from one hold.
+type releaseResult struct {
+	hold      *releaseHold
+	custodian otlh.Custodian
+	result    string
+	reason    string
+}
+
+// fetchLegalholdToRelease gets a legal hold with its custodians and history.
+func fetchLegalholdToRelease(ctx *cli.Context, client *otlh.Client, legalhold otlh.Legalhold) (*releaseHold, error) {
+	custodians, err := client.GetLegalholdCustodiansContext(ctx.Context, legalhold)
+	if err != nil {
+		return nil, err
+	}
+	history, err := client.GetLegalholdHistoryContext(ctx.Context, legalhold)
+	if err != nil {
+		return nil, err
+	}
+	return &releaseHold{kind: "legal hold", id: legalhold.ID, name: legalhold.Name, custodians: custodians, history: history}, nil
+}
+
+// fetchSilentholdToRelease gets a silent hold with its custodians and history.
+func fetchSilentholdToRelease(ctx *cli.Context, client *otlh.Client, silenthold otlh.Silenthold) (*releaseHold, error) {
+	custodians, err := client.GetSilentholdCustodiansContext(ctx.Context, silenthold)
+	if err != nil {
+		return nil, err
+	}
+	history, err := client.GetSilentholdHistoryContext(ctx.Context, silenthold)
+	if err != nil {
+		return nil, err
+	}
+	return &releaseHold{kind: "silent hold", id: silenthold.ID, name: silenthold.Name, custodians: custodians, history: history}, nil
+}
+
+/*
+selectHoldsToRelease returns the legal hold of --legalHoldID, the silent hold
+of --silentHoldID, or every legal and silent hold of the matter of --matterID.
+*/
+func selectHoldsToRelease(ctx *cli.Context, client *otlh.Client) ([]*releaseHold, error) {
+	var selected int
+	for _, name := range []string{"legalHoldID", "silentHoldID", "matterID"} {
+		if ctx.Int(name) > 0 {
+			selected++
+		}
+	}
+	if selected != 1 {
+		return nil, fmt.Errorf("pass one of --legalHoldID, --silentHoldID or --matterID")
+	}
+
+	switch {
+	case ctx.Int("legalHoldID") > 0:
+		req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Legalhold().WithID(ctx.Int("legalHoldID")).Build()
+		legalhold, err := client.GetLegalholdContext(ctx.Context, req)
+		if err != nil {
+			return nil, err
+		}
+		hold, err := fetchLegalholdToRelease(ctx, client, legalhold)
+		if err != nil {
+			return nil, err
+		}
+		return []*releaseHold{hold}, nil
+
+	case ctx.Int("silentHoldID") > 0:
+		req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Silenthold().WithID(ctx.Int("silentHoldID")).Build()
+		silenthold, err := client.GetSilentholdContext(ctx.Context, req)
+		if err != nil {
+			return nil, err
+		}
+		hold, err := fetchSilentholdToRelease(ctx, client, silenthold)
+		if err != nil {
+			return nil, err
+		}
+		return []*releaseHold{hold}, nil
+	}
+
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Matter().WithID(ctx.Int("matterID")).Build()
+	matter, err := client.GetMatterContext(ctx.Context, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var holds []*releaseHold
+
+	legalholds, err := client.GetMatterLegalholdsContext(ctx.Context, matter)
+	if err != nil {
+		return nil, err
+	}
+	for _, legalhold := range legalholds {
+		hold, err := fetchLegalholdToRelease(ctx, client, legalhold)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, hold)
+	}
+
+	// matters have no link to their silent holds
+	shReq, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Silenthold().Build()
+	for silenthold, err := range client.IterSilentholdsContext(ctx.Context, shReq, otlh.NewListOptions()) {
+		if err != nil {
+			return nil, err
+		}
+		if silenthold.MatterID != matter.ID {
+			continue
+		}
+		hold, err := fetchSilentholdToRelease(ctx, client, silenthold)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, hold)
+	}
+
+	return holds, nil
+}
+
+// selectCustodiansToRelease returns the ids of --custodianIDs or the emails of --emailFile.
+func selectCustodiansToRelease(ctx *cli.Context) ([]int, []string, error) {
+	ids := ctx.IntSlice("custodianIDs")
+
+	path := ctx.String("emailFile")
+	if path == "" {
+		return ids, nil, nil
+	}
+	if len(ids) > 0 {
+		return nil, nil, fmt.Errorf("pass only one of --custodianIDs or --emailFile")
+	}
+
+	emails, err := readEmails(path)
+	return nil, emails, err
+}
+
+/*
+planRelease returns a result for every custodian to release from holds: all of
+them, or those with ids or emails. Custodians whose latest membership event in
+the history of the hold is a release are skipped; selected custodians that are
+on none of the holds are an error.
+*/
+func planRelease(holds []*releaseHold, ids []int, emails []string) ([]releaseResult, error) {
+	everyone := len(ids) == 0 && len(emails) == 0
+	found := map[string]bool{}
+
+	var results []releaseResult
+	for _, hold := range holds {
+		memberIDs := make([]int, len(hold.custodians))
+		for i, custodian := range hold.custodians {
+			memberIDs[i] = custodian.ID
+		}
+		released := hold.history.Released(memberIDs)
+
+		for _, custodian := range hold.custodians {
+			if !everyone {
+				byID := slices.Contains(ids, custodian.ID)
+				byEmail := slices.ContainsFunc(emails, func(email string) bool { return strings.EqualFold(email, custodian.Email) })
+				if !byID && !byEmail {
+					continue
+				}
+				found[strconv.Itoa(custodian.ID)] = true
+				found[strings.ToLower(custodian.Email)] = true
+			}
+
+			r := releaseResult{hold: hold, custodian: custodian, result: "pending"}
+			if slices.Contains(released, custodian.ID) {
+				r.result, r.reason = "skipped", "already released"
+			}
+			results = append(results, r)
+		}
+	}
+
+	var missing []string
+	for _, id := range ids {
+		if !found[strconv.Itoa(id)] {
+			missing = append(missing, strconv.Itoa(id))
+		}
+	}
+	for _, email := range emails {
+		if !found[strings.ToLower(email)] {
+			missing = append(missing, email)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("custodians not on the selected holds: %s", strings.Join(missing, ", "))
+	}
+
+	return results, nil
+}
+
+// releaseReportHeader is the header of the per-custodian report of release.
+var releaseReportHeader = []string{"Hold Type", "Hold ID", "Hold Name", "Custodian ID", "Custodian Name", "Custodian Email", "Result", "Reason"}
+
+func releaseReportRow(r releaseResult) []string {
+	return []string{r.hold.kind, strconv.Itoa(r.hold.id), r.hold.name, strconv.Itoa(r.custodian.ID), r.custodian.Name, r.custodian.Email, r.result, r.reason}
+}
+
+/*
+writeReleaseReport writes one line per custodian and hold to out as CSV, or
+prints them as a table when out is nil.
+*/
+func writeReleaseReport(out io.Writer, results []releaseResult) error {
+	if out != nil {
+		return writeReleaseCSV(out, results)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "HOLD\tHOLD NAME\tCUSTODIAN ID\tEMAIL\tRESULT\tREASON")
+	for _, r := range results {
+		reason := r.reason
+		if reason == "" {
+			reason = "-"
+		}
+		fmt.Fprintf(w, "%s %d\t%s\t%d\t%s\t%s\t%s\n", r.hold.kind, r.hold.id, r.hold.name, r.custodian.ID, r.custodian.Email, r.result, reason)
+	}
+	return w.Flush()
+}
+
+func writeReleaseCSV(w io.Writer, results []releaseResult) error {
+	cw := csv.NewWriter(w)
+	cw.Write(releaseReportHeader)
+	for _, r := range results {
+		cw.Write(releaseReportRow(r))
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+/*
+releaseCustodians releases every pending custodian of results with its own
+request, so that each gets its own result. Once ctx is done, e.g. on Ctrl-C,
+no further request is sent and the custodians left are "not attempted".
+*/
+func releaseCustodians(ctx context.Context, client *otlh.Client, results []releaseResult, sendNotice bool) (released, skipped, failed, notAttempted int) {
+	for i := range results {
+		r := &results[i]
+		if r.result != "pending" {
+			skipped++
+			continue
+		}
+
+		if ctx.Err() != nil {
+			r.result, r.reason = "not attempted", "interrupted"
+			notAttempted++
+			continue
+		}
+
+		var err error
+		if r.hold.kind == "legal hold" {
+			err = client.ReleaseLegalholdCustodiansContext(ctx, r.hold.id, []int{r.custodian.ID}, sendNotice)
+		} else {
+			err = client.ReleaseSilentholdCustodiansContext(ctx, r.hold.id, []int{r.custodian.ID}, sendNotice)
+		}
+		if err != nil {
+			r.result, r.reason = "failed", err.Error()
+			failed++
+			continue
+		}
+		r.result = "released"
+		released++
+	}
+
+	return released, skipped, failed, notAttempted
+}
+
+func release(ctx *cli.Context) error {
+	client := NewClient(ctx)
+
+	holds, err := selectHoldsToRelease(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	ids, emails, err := selectCustodiansToRelease(ctx)
+	if err != nil {
+		return err
+	}
+
+	results, err := planRelease(holds, ids, emails)
+	if err != nil {
+		return err
+	}
+
+	counts := map[*releaseHold]int{}
+	var releasable int
+	for _, r := range results {
+		if r.result == "pending" {
+			counts[r.hold]++
+			releasable++
+		}
+	}
+
+	// create the report file before releasing, the releases can not be undone
+	// and their report must not be lost to a path that can not be written
+	var report io.Writer
+	if outputFile := ctx.String("outputFile"); outputFile != "" {
+		f, err := os.Create(outputFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		report = f
+	}
+
+	if releasable == 0 {
+		if err = writeReleaseReport(report, results); err != nil {
+			return err
+		}
+		fmt.Println("no custodians to release")
+		return nil
+	}
+
+	what := fmt.Sprintf("%d custodians from %d holds", releasable, len(counts))
+	if ctx.Bool("sendReleaseNotice") {
+		what += " and send them the release notice"
+	}
+
+	if ctx.Bool("dryRun") {
+		for i := range results {
+			if results[i].result == "pending" {
+				results[i].result = "dry run"
+			}
+		}
+		if err = writeReleaseReport(report, results); err != nil {
+			return err
+		}
+		fmt.Printf("dry run: would release %s\n", what)
+		return nil
+	}
+
+	if !confirm(ctx, "Release "+what+", this can not be undone") {
+		fmt.Println("aborted")
+		return nil
+	}
+
+	released, skipped, failed, notAttempted := releaseCustodians(ctx.Context, client, results, ctx.Bool("sendReleaseNotice"))
+
+	if err = writeReleaseReport(report, results); err != nil {
+		return err
+	}
+	fmt.Printf("released %d, skipped %d, failed %d, not attempted %d\n", released, skipped, failed, notAttempted)
+
+	if notAttempted > 0 {
+		return fmt.Errorf("release interrupted, %d custodians not attempted: %w", notAttempted, ctx.Context.Err())
+	}
+	if failed > 0 {
+		return fmt.Errorf("release failed for %d custodians", failed)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestMain(m *testing.M) {
+	// keep the debug and trace output of the client out of the test output
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	os.Exit(m.Run())
+}
+
+func releaseEvent(custodian otlh.Custodian, event string, createdAt string) otlh.HistoryEvent {
+	e := otlh.HistoryEvent{Event: event, CreatedAt: createdAt}
+	e.Custodian.ID = custodian.ID
+	e.Custodian.Email = custodian.Email
+	return e
+}
+
+func TestPlanRelease(t *testing.T) {
+	jane := otlh.Custodian{ID: 1, Name: "Jane Doe", Email: "jane@acme.com"}
+	john := otlh.Custodian{ID: 2, Name: "John Doe", Email: "john@acme.com"}
+	mary := otlh.Custodian{ID: 3, Name: "Mary Roe", Email: "mary@acme.com"}
+
+	legalhold := &releaseHold{
+		kind:       "legal hold",
+		id:         10,
+		custodians: otlh.Custodians{jane, john, mary},
+		history: otlh.HistoryEvents{
+			releaseEvent(jane, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+			releaseEvent(john, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+			releaseEvent(john, otlh.HISTORY_ADDED, "2026-03-01T00:00:00Z"),
+		},
+	}
+	silenthold := &releaseHold{
+		kind:       "silent hold",
+		id:         20,
+		custodians: otlh.Custodians{jane},
+	}
+	holds := []*releaseHold{legalhold, silenthold}
+
+	type planned struct {
+		holdID      int
+		custodianID int
+		result      string
+	}
+
+	tests := []struct {
+		name    string
+		ids     []int
+		emails  []string
+		want    []planned
+		wantErr bool
+	}{
+		{
+			name: "everyone",
+			want: []planned{{10, 1, "skipped"}, {10, 2, "pending"}, {10, 3, "pending"}, {20, 1, "pending"}},
+		},
+		{
+			name: "by id",
+			ids:  []int{1},
+			want: []planned{{10, 1, "skipped"}, {20, 1, "pending"}},
+		},
+		{
+			name:   "by email, ignoring case",
+			emails: []string{"JOHN@acme.com"},
+			want:   []planned{{10, 2, "pending"}},
+		},
+		{
+			name:    "custodian on no hold",
+			ids:     []int{1, 4},
+			wantErr: true,
+		},
+		{
+			name:    "email on no hold",
+			emails:  []string{"nobody@acme.com"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := planRelease(holds, tt.ids, tt.emails)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+
+			var got []planned
+			for _, r := range results {
+				got = append(got, planned{r.hold.id, r.custodian.ID, r.result})
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseCustodiansStopsWhenCancelled(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	var custodians otlh.Custodians
+	for _, email := range []string{"jane@acme.com", "john@acme.com", "mary@acme.com", "max@acme.com"} {
+		custodians = append(custodians, srv.AddCustodian(otlh.Custodian{Name: email, Email: email}))
+	}
+	ids := make([]int, len(custodians))
+	for i, c := range custodians {
+		ids[i] = c.ID
+	}
+	legalhold := srv.AddLegalhold(otlh.Legalhold{Name: "Acme"}, ids...)
+
+	client := srv.ClientBuilder().WithRetryPolicy(otlh.RetryPolicy{MaxAttempts: 1}).Build()
+
+	plan := func() []releaseResult {
+		results, err := planRelease([]*releaseHold{{kind: "legal hold", id: legalhold.ID, custodians: custodians}}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return results
+	}
+
+	t.Run("cancelled before the first request", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results := plan()
+		sent := len(srv.Requests())
+		released, _, failed, notAttempted := releaseCustodians(ctx, client, results, false)
+
+		if released != 0 || failed != 0 || notAttempted != len(custodians) {
+			t.Fatalf("got released %d, failed %d, not attempted %d, want all not attempted", released, failed, notAttempted)
+		}
+		if n := len(srv.Requests()) - sent; n != 0 {
+			t.Fatalf("got %d requests, want none", n)
+		}
+	})
+
+	t.Run("cancelled while releasing", func(t *testing.T) {
+		srv.InjectFault(otlhtest.Fault{Method: http.MethodPost, Path: "/release_custodians", Delay: 50 * time.Millisecond})
+		defer srv.ClearFaults()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 75*time.Millisecond)
+		defer cancel()
+
+		results := plan()
+		sent := len(srv.Requests())
+		released, _, failed, notAttempted := releaseCustodians(ctx, client, results, false)
+
+		if notAttempted == 0 || released+failed+notAttempted != len(custodians) {
+			t.Fatalf("got released %d, failed %d, not attempted %d, want the last ones not attempted", released, failed, notAttempted)
+		}
+		if n := len(srv.Requests()) - sent; n != released+failed {
+			t.Fatalf("got %d requests for %d attempted custodians", n, released+failed)
+		}
+		for _, r := range results[len(results)-notAttempted:] {
+			if r.result != "not attempted" {
+				t.Fatalf("got result %q for custodian %d after cancelling, want not attempted", r.result, r.custodian.ID)
+			}
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+	otlh "github.com/xifanyan/otlh/pkg"
+)
+
+/*
+selectLegalholds returns the legal hold of --legalHoldID, or every legal hold
+of the matter of --matterID or of the matters in the folder of --folderID.
+*/
+func selectLegalholds(ctx *cli.Context, client *otlh.Client) (otlh.Legalholds, error) {
+	var selected int
+	for _, name := range []string{"legalHoldID", "matterID", "folderID"} {
+		if ctx.Int(name) > 0 {
+			selected++
+		}
+	}
+	if selected != 1 {
+		return nil, fmt.Errorf("pass one of --legalHoldID, --matterID or --folderID")
+	}
+
+	switch {
+	case ctx.Int("legalHoldID") > 0:
+		req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Legalhold().WithID(ctx.Int("legalHoldID")).Build()
+		legalhold, err := client.GetLegalholdContext(ctx.Context, req)
+		if err != nil {
+			return nil, err
+		}
+		return otlh.Legalholds{legalhold}, nil
+
+	case ctx.Int("matterID") > 0:
+		req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Matter().WithID(ctx.Int("matterID")).Build()
+		matter, err := client.GetMatterContext(ctx.Context, req)
+		if err != nil {
+			return nil, err
+		}
+		return client.GetMatterLegalholdsContext(ctx.Context, matter)
+	}
+
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Folder().WithID(ctx.Int("folderID")).Build()
+	folder, err := client.GetFolderContext(ctx.Context, req)
+	if err != nil {
+		return nil, err
+	}
+
+	matters, err := client.GetFolderMattersContext(ctx.Context, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	var legalholds otlh.Legalholds
+	for _, matter := range matters {
+		holds, err := client.GetMatterLegalholdsContext(ctx.Context, matter)
+		if err != nil {
+			return nil, err
+		}
+		legalholds = append(legalholds, holds...)
+	}
+	return legalholds, nil
+}
+
+// reminderResult is the outcome of sending the reminders of one legal hold.
+type reminderResult struct {
+	legalhold otlh.Legalhold
+	result    string
+	reason    string
+}
+
+// printReminderResults prints one line per legal hold.
+func printReminderResults(results []reminderResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tRESULT\tREASON")
+	for _, r := range results {
+		reason := r.reason
+		if reason == "" {
+			reason = "-"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", r.legalhold.ID, r.legalhold.Name, r.result, reason)
+	}
+	w.Flush()
+}
+
+/*
+planReminders returns a pending result for each legal hold allowing reminders
+of type t, a skipped one with the service's reason for the others, and the
+number of pending results.
+*/
+func planReminders(legalholds otlh.Legalholds, t otlh.ReminderType) ([]reminderResult, int) {
+	results := make([]reminderResult, len(legalholds))
+	var sendable int
+	for i, legalhold := range legalholds {
+		results[i] = reminderResult{legalhold: legalhold, result: "pending"}
+		if ok, reason := legalhold.CanSendReminders(t); !ok {
+			results[i].result, results[i].reason = "skipped", reason
+			continue
+		}
+		sendable++
+	}
+	return results, sendable
+}
+
+/*
+sendLegalholdReminders sends the reminders of every pending legal hold of
+results. Once ctx is done, e.g. on Ctrl-C, no further request is sent and the
+holds left are "not attempted".
+*/
+func sendLegalholdReminders(ctx context.Context, client *otlh.Client, results []reminderResult, t otlh.ReminderType) (sent, skipped, failed, notAttempted int) {
+	for i := range results {
+		r := &results[i]
+		if r.result != "pending" {
+			skipped++
+			continue
+		}
+
+		if ctx.Err() != nil {
+			r.result, r.reason = "not attempted", "interrupted"
+			notAttempted++
+			continue
+		}
+
+		if err := client.SendLegalholdRemindersContext(ctx, r.legalhold, t); err != nil {
+			r.result, r.reason = "failed", err.Error()
+			failed++
+			continue
+		}
+		r.result = "sent"
+		sent++
+	}
+
+	return sent, skipped, failed, notAttempted
+}
+
+func sendReminders(ctx *cli.Context) error {
+	reminderType, err := otlh.ParseReminderType(ctx.String("type"))
+	if err != nil {
+		return err
+	}
+
+	client := NewClient(ctx)
+
+	legalholds, err := selectLegalholds(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	results, sendable := planReminders(legalholds, reminderType)
+
+	if sendable == 0 {
+		printReminderResults(results)
+		fmt.Printf("no legal hold allows %s reminders, nothing to send\n", reminderType)
+		return nil
+	}
+
+	what := fmt.Sprintf("%s reminders of %d legal holds", reminderType, sendable)
+
+	if ctx.Bool("dryRun") {
+		for i := range results {
+			if results[i].result == "pending" {
+				results[i].result = "dry run"
+			}
+		}
+		printReminderResults(results)
+		fmt.Printf("dry run: would send %s\n", what)
+		return nil
+	}
+
+	if !confirm(ctx, "Send "+what+", this can not be undone") {
+		fmt.Println("aborted")
+		return nil
+	}
+
+	sent, skipped, failed, notAttempted := sendLegalholdReminders(ctx.Context, client, results, reminderType)
+
+	printReminderResults(results)
+	fmt.Printf("sent %d, skipped %d, failed %d, not attempted %d\n", sent, skipped, failed, notAttempted)
+
+	if notAttempted > 0 {
+		return fmt.Errorf("sending interrupted, %d legal holds not attempted: %w", notAttempted, ctx.Context.Err())
+	}
+	if failed > 0 {
+		return fmt.Errorf("%s failed for %d legal holds", what, failed)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestSendLegalholdReminders(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	jane := srv.AddCustodian(otlh.Custodian{Name: "Jane Doe", Email: "jane@acme.com"})
+
+	denied := otlh.Legalhold{Name: "Denied"}
+	denied.CanSendHoldReminders.Code, denied.CanSendHoldReminders.Reason = 2, "hold reminders are disabled"
+
+	legalholds := otlh.Legalholds{
+		srv.AddLegalhold(otlh.Legalhold{Name: "First"}, jane.ID),
+		srv.AddLegalhold(denied, jane.ID),
+		srv.AddLegalhold(otlh.Legalhold{Name: "Third"}, jane.ID),
+		srv.AddLegalhold(otlh.Legalhold{Name: "Fourth"}, jane.ID),
+	}
+
+	client := srv.ClientBuilder().WithRetryPolicy(otlh.RetryPolicy{MaxAttempts: 1}).Build()
+
+	tests := []struct {
+		name    string
+		fault   *otlhtest.Fault
+		timeout time.Duration
+		want    []string
+	}{
+		{
+			name: "sent and skipped",
+			want: []string{"sent", "skipped", "sent", "sent"},
+		},
+		{
+			name:  "failed",
+			fault: &otlhtest.Fault{Method: http.MethodPost, Path: "/send_hold_reminders", StatusCode: http.StatusInternalServerError, Times: 1},
+			want:  []string{"failed", "skipped", "sent", "sent"},
+		},
+		{
+			name:    "cancelled before the first request",
+			timeout: -1,
+			want:    []string{"not attempted", "skipped", "not attempted", "not attempted"},
+		},
+		{
+			name:    "cancelled while sending",
+			fault:   &otlhtest.Fault{Method: http.MethodPost, Path: "/send_hold_reminders", Delay: 100 * time.Millisecond},
+			timeout: 150 * time.Millisecond,
+			want:    []string{"sent", "skipped", "failed", "not attempted"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fault != nil {
+				srv.InjectFault(*tt.fault)
+				defer srv.ClearFaults()
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.timeout > 0 {
+				ctx, cancel = context.WithTimeout(context.Background(), tt.timeout)
+			}
+			defer cancel()
+			if tt.timeout < 0 {
+				cancel()
+			}
+
+			results, sendable := planReminders(legalholds, otlh.HOLD_REMINDER)
+			if sendable != 3 {
+				t.Fatalf("got %d sendable holds, want 3", sendable)
+			}
+
+			requests := len(srv.Requests())
+			sent, skipped, failed, notAttempted := sendLegalholdReminders(ctx, client, results, otlh.HOLD_REMINDER)
+
+			var got []string
+			for _, r := range results {
+				got = append(got, r.result)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got results %v, want %v", got, tt.want)
+			}
+
+			count := func(result string) int {
+				return len(slices.DeleteFunc(slices.Clone(tt.want), func(s string) bool { return s != result }))
+			}
+			if sent != count("sent") || skipped != count("skipped") || failed != count("failed") || notAttempted != count("not attempted") {
+				t.Fatalf("got sent %d, skipped %d, failed %d, not attempted %d for %v", sent, skipped, failed, notAttempted, tt.want)
+			}
+			if n := len(srv.Requests()) - requests; n != sent+failed {
+				t.Fatalf("got %d requests for %d attempted holds", n, sent+failed)
+			}
+			if results[1].reason != "hold reminders are disabled" {
+				t.Fatalf("got reason %q for the skipped hold", results[1].reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+	otlh "github.com/xifanyan/otlh/pkg"
+)
+
+/*
+readEmails reads the emails of --emailFile: one per line, or a CSV file with
+an "email" column. Empty lines and lines starting with # are skipped.
+*/
+func readEmails(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	r.Comment = '#'
+	r.TrimLeadingSpace = true
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("email file %s: %w", path, err)
+	}
+
+	column := 0
+	if len(records) > 0 {
+		for i, header := range records[0] {
+			if strings.EqualFold(strings.TrimSpace(header), "email") {
+				column = i
+				records = records[1:]
+				break
+			}
+		}
+	}
+
+	var emails []string
+	for _, record := range records {
+		if column < len(record) && strings.TrimSpace(record[column]) != "" {
+			emails = append(emails, strings.TrimSpace(record[column]))
+		}
+	}
+
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("email file %s: no emails found", path)
+	}
+	return emails, nil
+}
+
+// printCustodians prints the id, name and email of custodians as a table.
+func printCustodians(custodians otlh.Custodians) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tEMAIL")
+	for _, custodian := range custodians {
+		fmt.Fprintf(w, "%d\t%s\t%s\n", custodian.ID, custodian.Name, custodian.Email)
+	}
+	w.Flush()
+}
+
+func sendNotice(ctx *cli.Context) error {
+	opts := otlh.NewSendNoticeOptions(ctx.Int("legalHoldID"))
+
+	var selectors int
+	if ctx.Bool("pendingOnly") {
+		opts.ToPendingCustodians()
+		selectors++
+	}
+	if ids := ctx.IntSlice("custodianIDs"); len(ids) > 0 {
+		opts.ToCustodianIDs(ids...)
+		selectors++
+	}
+	if path := ctx.String("emailFile"); path != "" {
+		emails, err := readEmails(path)
+		if err != nil {
+			return err
+		}
+		opts.ToEmails(emails...)
+		selectors++
+	}
+	if selectors > 1 {
+		return fmt.Errorf("pass only one of --pendingOnly, --custodianIDs or --emailFile")
+	}
+
+	client := NewClient(ctx)
+
+	legalhold, custodians, err := client.GetNoticeRecipientsContext(ctx.Context, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(custodians) == 0 {
+		fmt.Printf("no custodians selected on legal hold [%s] (id %d), nothing to send\n", legalhold.Name, legalhold.ID)
+		return nil
+	}
+
+	what := fmt.Sprintf("hold notice of legal hold [%s] (id %d) to %d custodians", legalhold.Name, legalhold.ID, len(custodians))
+
+	printCustodians(custodians)
+
+	if ctx.Bool("dryRun") {
+		fmt.Printf("dry run: would send %s\n", what)
+		return nil
+	}
+
+	if !confirm(ctx, "Send "+what+", this can not be undone") {
+		fmt.Println("aborted")
+		return nil
+	}
+
+	if err = client.SendLegalholdNoticeToContext(ctx.Context, legalhold.ID, custodians); err != nil {
+		return err
+	}
+
+	fmt.Printf("sent %s\n", what)
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+	otlh "github.com/xifanyan/otlh/pkg"
+)
+
+// statsReport is what get stats prints: the stats of each hold and their total.
+type statsReport struct {
+	Matter     *otlh.MatterStats     `json:"matter,omitempty"`
+	Legalholds []otlh.LegalholdStats `json:"legal_holds"`
+	Total      otlh.NoticeCounts     `json:"total"`
+}
+
+func getStats(ctx *cli.Context) error {
+	var selected int
+	for _, name := range []string{"legalHoldID", "matterID", "folderID"} {
+		if ctx.Int(name) > 0 {
+			selected++
+		}
+	}
+	if ctx.Bool("all") {
+		selected++
+	}
+	if selected != 1 {
+		return fmt.Errorf("pass one of --legalHoldID, --matterID, --folderID or --all")
+	}
+
+	output := ctx.String("output")
+	if output != "json" && output != "table" {
+		return fmt.Errorf("unsupported output %s, use json or table", output)
+	}
+
+	report, err := collectStats(ctx.Context, NewClient(ctx), ctx.Int("legalHoldID"), ctx.Int("matterID"), ctx.Int("folderID"))
+	if err != nil {
+		return err
+	}
+
+	if output == "table" {
+		return printStatsTable(os.Stdout, report)
+	}
+	return otlh.NewPrinter().JSON().Build().Print(report)
+}
+
+/*
+collectStats gets the stats of the legal hold with legalholdID, of the holds of
+the matter with matterID, of the holds of the matters in the folder with
+folderID or, when all are 0, of every legal hold, and totals them.
+*/
+func collectStats(ctx context.Context, client *otlh.Client, legalholdID int, matterID int, folderID int) (statsReport, error) {
+	report := statsReport{Legalholds: []otlh.LegalholdStats{}}
+
+	var legalholds otlh.Legalholds
+
+	switch {
+	case legalholdID > 0:
+		req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Legalhold().WithID(legalholdID).Build()
+		legalhold, err := client.GetLegalholdContext(ctx, req)
+		if err != nil {
+			return report, err
+		}
+		legalholds = otlh.Legalholds{legalhold}
+
+	case matterID > 0:
+		req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Matter().WithID(matterID).Build()
+		matter, err := client.GetMatterContext(ctx, req)
+		if err != nil {
+			return report, err
+		}
+
+		stats, err := client.GetMatterStatsContext(ctx, matter)
+		if err != nil {
+			return report, err
+		}
+		report.Matter = &stats
+
+		if legalholds, err = client.GetMatterLegalholdsContext(ctx, matter); err != nil {
+			return report, err
+		}
+
+	case folderID > 0:
+		req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Folder().WithID(folderID).Build()
+		folder, err := client.GetFolderContext(ctx, req)
+		if err != nil {
+			return report, err
+		}
+
+		stats, err := client.GetFolderLegalholdStatsContext(ctx, folder)
+		if err != nil {
+			return report, err
+		}
+		report.Legalholds = append(report.Legalholds, stats...)
+
+	default:
+		req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Legalhold().Build()
+		for legalhold, err := range client.IterLegalholdsContext(ctx, req, otlh.NewListOptions()) {
+			if err != nil {
+				return report, err
+			}
+			legalholds = append(legalholds, legalhold)
+		}
+	}
+
+	for _, legalhold := range legalholds {
+		stats, err := client.GetLegalholdStatsContext(ctx, legalhold)
+		if err != nil {
+			return report, err
+		}
+		report.Legalholds = append(report.Legalholds, stats)
+	}
+
+	for _, stats := range report.Legalholds {
+		report.Total = report.Total.Add(stats.NoticeCounts)
+	}
+
+	return report, nil
+}
+
+/*
+printStatsTable prints one row per hold, a total row when there are several
+and, for a matter, a row with the counts the service reports for the matter
+with its number of holds and custodians.
+*/
+func printStatsTable(out io.Writer, report statsReport) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	row := func(id string, name string, n otlh.NoticeCounts) {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%d\n", id, name, n.Issued, n.Acknowledged, n.Pending, n.Released, n.Overdue)
+	}
+
+	fmt.Fprintln(w, "ID\tHOLD\tISSUED\tACKNOWLEDGED\tPENDING\tRELEASED\tOVERDUE")
+	for _, stats := range report.Legalholds {
+		row(fmt.Sprint(stats.LegalholdID), stats.Name, stats.NoticeCounts)
+	}
+
+	if len(report.Legalholds) > 1 {
+		row("", "TOTAL", report.Total)
+	}
+
+	if m := report.Matter; m != nil {
+		row(fmt.Sprint(m.MatterID), fmt.Sprintf("MATTER %s (%d holds, %d custodians)", m.Name, m.LegalHolds, m.Custodians), m.NoticeCounts)
+	}
+
+	return w.Flush()
+}
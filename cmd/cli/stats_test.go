@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestCollectStats(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	folder := srv.AddFolder(otlh.Folder{Name: "Acme"})
+	matter := srv.AddMatter(otlh.Matter{Name: "Contract"}, folder.ID)
+	other := srv.AddMatter(otlh.Matter{Name: "Other"}, srv.AddFolder(otlh.Folder{Name: "Other"}).ID)
+
+	first := srv.AddLegalhold(otlh.Legalhold{Name: "First", MatterID: matter.ID})
+	second := srv.AddLegalhold(otlh.Legalhold{Name: "Second", MatterID: matter.ID})
+	third := srv.AddLegalhold(otlh.Legalhold{Name: "Third", MatterID: other.ID})
+	srv.SetLegalholdStats(first.ID, otlh.NoticeCounts{Issued: 4, Acknowledged: 3, Pending: 1})
+	srv.SetLegalholdStats(second.ID, otlh.NoticeCounts{Issued: 2, Pending: 1, Released: 1})
+	srv.SetLegalholdStats(third.ID, otlh.NoticeCounts{Issued: 1, Pending: 1, Overdue: 1})
+
+	client := srv.Client()
+
+	tests := []struct {
+		name        string
+		legalholdID int
+		matterID    int
+		folderID    int
+		wantHolds   []int
+		wantTotal   otlh.NoticeCounts
+		wantMatter  bool
+		wantRows    []string
+	}{
+		{
+			name:        "legal hold",
+			legalholdID: first.ID,
+			wantHolds:   []int{first.ID},
+			wantTotal:   otlh.NoticeCounts{Issued: 4, Acknowledged: 3, Pending: 1},
+			wantRows:    []string{"First"},
+		},
+		{
+			name:       "matter",
+			matterID:   matter.ID,
+			wantHolds:  []int{first.ID, second.ID},
+			wantTotal:  otlh.NoticeCounts{Issued: 6, Acknowledged: 3, Pending: 2, Released: 1},
+			wantMatter: true,
+			wantRows:   []string{"First", "Second", "TOTAL", "MATTER Contract (2 holds, 0 custodians)"},
+		},
+		{
+			name:      "folder",
+			folderID:  folder.ID,
+			wantHolds: []int{first.ID, second.ID},
+			wantTotal: otlh.NoticeCounts{Issued: 6, Acknowledged: 3, Pending: 2, Released: 1},
+			wantRows:  []string{"First", "Second", "TOTAL"},
+		},
+		{
+			name:      "all",
+			wantHolds: []int{first.ID, second.ID, third.ID},
+			wantTotal: otlh.NoticeCounts{Issued: 7, Acknowledged: 3, Pending: 3, Released: 1, Overdue: 1},
+			wantRows:  []string{"First", "Second", "Third", "TOTAL"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := collectStats(context.Background(), client, tt.legalholdID, tt.matterID, tt.folderID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var holds []int
+			for _, stats := range report.Legalholds {
+				holds = append(holds, stats.LegalholdID)
+			}
+			if !slices.Equal(holds, tt.wantHolds) {
+				t.Fatalf("got holds %v, want %v", holds, tt.wantHolds)
+			}
+			if report.Total != tt.wantTotal {
+				t.Fatalf("got total %+v, want %+v", report.Total, tt.wantTotal)
+			}
+			if (report.Matter != nil) != tt.wantMatter {
+				t.Fatalf("got matter stats %+v, want them: %v", report.Matter, tt.wantMatter)
+			}
+
+			var buf bytes.Buffer
+			if err = printStatsTable(&buf, report); err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != len(tt.wantRows)+1 {
+				t.Fatalf("got table\n%s\nwant a header and %d rows", buf.String(), len(tt.wantRows))
+			}
+			for i, row := range tt.wantRows {
+				if !strings.Contains(lines[i+1], row) {
+					t.Fatalf("got row %q, want %q in it", lines[i+1], row)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/xifanyan/otlh
 
-go 1.22
+go 1.23
 
 toolchain go1.23.1
 
@@ -11,6 +11,7 @@ require (
 	github.com/schollz/progressbar/v3 v3.16.0
 	github.com/urfave/cli/v2 v2.27.1
 	github.com/xuri/excelize/v2 v2.8.1
+	golang.org/x/net v0.22.0
 )
 
 require (
@@ -27,7 +28,6 @@ require (
 	github.com/xuri/efp v0.0.0-20231025114914-d1ff6096ae53 // indirect
 	github.com/xuri/nfp v0.0.0-20230919160717-d98342af3f05 // indirect
 	golang.org/x/crypto v0.21.0 // indirect
-	golang.org/x/net v0.22.0 // indirect
 	golang.org/x/sys v0.25.0 // indirect
 	golang.org/x/term v0.24.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
@@ -0,0 +1,392 @@
+package otlh
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// lines of a journal can hold long error messages, allow up to 1 MiB per line
+const MAX_AUDIT_LINE_SIZE = 1 << 20
+
+/*
+AuditEntry records one mutating API call. Hash is the SHA-256 of the entry
+serialized without Hash, and PrevHash the Hash of the entry before it, so
+changing, removing or reordering any line but the last ones breaks the chain
+from there on.
+*/
+type AuditEntry struct {
+	Seq      int       `json:"seq"`
+	Time     time.Time `json:"time"`
+	Operator string    `json:"operator"`
+	Tenant   string    `json:"tenant"`
+	Method   string    `json:"method"`
+	Endpoint string    `json:"endpoint"`
+
+	// BodySHA256 is the digest of the JSON body sent, Files the digest of
+	// each uploaded file (e.g. a hold import package) by form field.
+	BodySHA256 string            `json:"body_sha256,omitempty"`
+	Files      map[string]string `json:"files_sha256,omitempty"`
+
+	// Status is 0 when no response was received, see Error.
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+
+	// Entity is the kind of entity the endpoint addresses, e.g. "matters".
+	// EntityIDs are the ids in the endpoint and the response, MatterID and
+	// HoldID the matter and hold the request or response refer to.
+	Entity    string `json:"entity,omitempty"`
+	EntityIDs []int  `json:"entity_ids,omitempty"`
+	MatterID  int    `json:"matter_id,omitempty"`
+	HoldID    int    `json:"hold_id,omitempty"`
+
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+func (e AuditEntry) computeHash() string {
+	e.Hash = ""
+	data, _ := json.Marshal(e)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// HasMatter reports whether the entry is about the matter with id, or a hold of it.
+func (e AuditEntry) HasMatter(id int) bool {
+	return e.MatterID == id || (e.Entity == "matters" && slices.Contains(e.EntityIDs, id))
+}
+
+// HasHold reports whether the entry is about the legal or silent hold with id.
+func (e AuditEntry) HasHold(id int) bool {
+	if e.HoldID == id {
+		return true
+	}
+	return (e.Entity == "legal_holds" || e.Entity == "silent_holds") && slices.Contains(e.EntityIDs, id)
+}
+
+/*
+AuditJournal appends AuditEntry lines to a JSONL file. Entries are written
+and synced one at a time, in the order the calls complete.
+
+Only one process should append to a journal at a time, entries written
+concurrently by two processes break the chain.
+*/
+type AuditJournal struct {
+	mu       sync.Mutex
+	path     string
+	operator string
+	file     *os.File
+	seq      int
+	lastHash string
+}
+
+/*
+OpenAuditJournal opens, or creates, the journal at path for appending entries
+on behalf of operator. It fails when the last line of an existing journal can
+not be read, as new entries could not be chained to it.
+*/
+func OpenAuditJournal(path string, operator string) (*AuditJournal, error) {
+	j := &AuditJournal{path: path, operator: operator}
+
+	err := scanAuditJournal(path, func(line int, entry AuditEntry, raw []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		j.seq = entry.Seq
+		j.lastHash = entry.Hash
+		return nil
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if j.file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600); err != nil {
+		return nil, fmt.Errorf("audit journal: %w", err)
+	}
+
+	return j, nil
+}
+
+func (j *AuditJournal) Path() string {
+	return j.path
+}
+
+func (j *AuditJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
+
+// Append chains entry to the journal, filling in Seq, Operator, PrevHash and Hash.
+func (j *AuditJournal) Append(entry AuditEntry) (AuditEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry.Seq = j.seq + 1
+	entry.Operator = j.operator
+	entry.PrevHash = j.lastHash
+	entry.Hash = entry.computeHash()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return entry, err
+	}
+
+	if _, err = j.file.Write(append(data, '\n')); err != nil {
+		return entry, fmt.Errorf("audit journal: %w", err)
+	}
+	if err = j.file.Sync(); err != nil {
+		return entry, fmt.Errorf("audit journal: %w", err)
+	}
+
+	j.seq = entry.Seq
+	j.lastHash = entry.Hash
+	return entry, nil
+}
+
+// scanAuditJournal calls fn with every line of the journal, err is set when the line is not an entry.
+func scanAuditJournal(path string, fn func(line int, entry AuditEntry, raw []byte, err error) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), MAX_AUDIT_LINE_SIZE)
+
+	for line := 1; scanner.Scan(); line++ {
+		var entry AuditEntry
+		raw := scanner.Bytes()
+		if err = json.Unmarshal(raw, &entry); err != nil {
+			err = fmt.Errorf("audit journal %s line %d: %w", path, line, err)
+		}
+		if err = fn(line, entry, raw, err); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// ReadAuditJournal returns the entries of the journal at path without verifying the chain.
+func ReadAuditJournal(path string) ([]AuditEntry, error) {
+	var entries []AuditEntry
+
+	err := scanAuditJournal(path, func(line int, entry AuditEntry, raw []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+
+	return entries, err
+}
+
+/*
+AuditHead identifies the last entry of a journal by its sequence number and
+hash. Recorded outside the journal, e.g. in a migration ticket, it anchors the
+chain: see VerifyAuditJournal.
+*/
+type AuditHead struct {
+	Seq  int    `json:"seq"`
+	Hash string `json:"hash"`
+}
+
+// String formats the head as seq:hash, the form ParseAuditHead reads.
+func (h AuditHead) String() string {
+	return fmt.Sprintf("%d:%s", h.Seq, h.Hash)
+}
+
+// ParseAuditHead parses a head in the seq:hash form printed by String.
+func ParseAuditHead(s string) (AuditHead, error) {
+	seq, hash, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return AuditHead{}, fmt.Errorf("invalid audit head %q, expected seq:hash", s)
+	}
+
+	n, err := strconv.Atoi(seq)
+	if err != nil || n < 1 {
+		return AuditHead{}, fmt.Errorf("invalid audit head %q: sequence must be a positive number", s)
+	}
+	if _, err = hex.DecodeString(hash); err != nil || len(hash) != sha256.Size*2 {
+		return AuditHead{}, fmt.Errorf("invalid audit head %q: hash must be a hex SHA-256", s)
+	}
+
+	return AuditHead{Seq: n, Hash: hash}, nil
+}
+
+/*
+VerifyAuditJournal checks every line of the journal at path: the sequence
+numbers, the link to the previous entry and the entry's own hash. It returns
+the head of the last entry verified and an error naming the first line that
+fails.
+
+The chain alone can not tell a journal cut off after any entry from a
+complete one, and anyone able to write the file can recompute every hash
+after changing it. Both are only caught against heads recorded earlier out of
+reach of the journal: each of anchors must still be in the journal with the
+same hash.
+*/
+func VerifyAuditJournal(path string, anchors ...AuditHead) (AuditHead, error) {
+	var head AuditHead
+
+	err := scanAuditJournal(path, func(line int, entry AuditEntry, raw []byte, err error) error {
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case entry.Seq != head.Seq+1:
+			return fmt.Errorf("line %d: sequence %d, expected %d: entries were removed or reordered", line, entry.Seq, head.Seq+1)
+		case entry.PrevHash != head.Hash:
+			return fmt.Errorf("line %d: previous hash does not match line %d", line, line-1)
+		case entry.Hash != entry.computeHash():
+			return fmt.Errorf("line %d: hash mismatch, the entry was modified", line)
+		}
+
+		// fields unknown to AuditEntry are not covered by the hash, reject them
+		if canonical, _ := json.Marshal(entry); !bytes.Equal(canonical, raw) {
+			return fmt.Errorf("line %d: entry is not in canonical form, the entry was modified", line)
+		}
+
+		for _, anchor := range anchors {
+			if anchor.Seq == entry.Seq && anchor.Hash != entry.Hash {
+				return fmt.Errorf("line %d: hash differs from anchor %s, the journal was rewritten", line, anchor)
+			}
+		}
+
+		head = AuditHead{Seq: entry.Seq, Hash: entry.Hash}
+		return nil
+	})
+	if err != nil {
+		return head, err
+	}
+
+	for _, anchor := range anchors {
+		if anchor.Seq > head.Seq {
+			return head, fmt.Errorf("journal ends at sequence %d before anchor %s: entries were removed from the end", head.Seq, anchor)
+		}
+	}
+
+	return head, nil
+}
+
+/*
+audit appends the outcome of a mutating request to the client's journal:
+the final response, or sendErr when none was received. A failure to write the
+journal is logged, the request has been sent at this point.
+*/
+func (c *Client) audit(req Requestor, opts []Options, resp *resty.Response, sendErr error) {
+	if c.auditJournal == nil || req.Method() == GET {
+		return
+	}
+
+	var status int
+	var respBody []byte
+	if resp != nil {
+		status = resp.StatusCode()
+		respBody = resp.Body()
+	}
+
+	if _, err := c.auditJournal.Append(c.auditEntryFor(req, opts, status, respBody, sendErr)); err != nil {
+		log.Error().Msgf("failed to record %s %s in audit journal %s: %s", req.Method(), req.Endpoint(), c.auditJournal.Path(), err)
+	}
+}
+
+// auditEntryFor describes req and its outcome, without the chain fields set by Append.
+func (c *Client) auditEntryFor(req Requestor, opts []Options, status int, respBody []byte, sendErr error) AuditEntry {
+	entry := AuditEntry{
+		Time:     time.Now().UTC(),
+		Tenant:   c.tenant,
+		Method:   req.Method().String(),
+		Endpoint: req.Endpoint(),
+		Status:   status,
+	}
+
+	if sendErr != nil {
+		entry.Error = sendErr.Error()
+	}
+
+	var reqBody string
+	for _, opt := range opts {
+		switch opt.optionType() {
+		case BODY:
+			reqBody = opt.options()["body"]
+			sum := sha256.Sum256([]byte(reqBody))
+			entry.BodySHA256 = hex.EncodeToString(sum[:])
+		case FILE:
+			entry.Files = make(map[string]string)
+			for field, path := range opt.options() {
+				entry.Files[field] = fileSHA256(path)
+			}
+		}
+	}
+
+	// /t/<tenant>/api/v3/<entity>[/<id>[/...]]
+	parts := strings.Split(strings.Trim(entry.Endpoint, "/"), "/")
+	if len(parts) > 4 {
+		entry.Entity = parts[4]
+	}
+	if len(parts) > 5 {
+		if id, err := strconv.Atoi(parts[5]); err == nil {
+			entry.EntityIDs = append(entry.EntityIDs, id)
+		}
+	}
+
+	for _, body := range []string{reqBody, string(respBody)} {
+		var fields struct {
+			ID           int `json:"id"`
+			MatterID     int `json:"matter_id"`
+			LegalholdID  int `json:"legal_hold_id"`
+			SilentholdID int `json:"silent_hold_id"`
+		}
+		if json.Unmarshal([]byte(body), &fields) != nil {
+			continue
+		}
+
+		if fields.ID > 0 && !slices.Contains(entry.EntityIDs, fields.ID) {
+			entry.EntityIDs = append(entry.EntityIDs, fields.ID)
+		}
+		if fields.MatterID > 0 {
+			entry.MatterID = fields.MatterID
+		}
+		if fields.LegalholdID > 0 {
+			entry.HoldID = fields.LegalholdID
+		}
+		if fields.SilentholdID > 0 {
+			entry.HoldID = fields.SilentholdID
+		}
+	}
+
+	return entry
+}
+
+func fileSHA256(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,234 @@
+package otlh_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+// recordAuditJournal creates n folders through a client journaling to a new file and returns its lines.
+func recordAuditJournal(t *testing.T, n int) [][]byte {
+	t.Helper()
+
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+	group := srv.AddGroup(otlh.Group{Name: "All Admins"})
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	journal, err := otlh.OpenAuditJournal(path, "alice@laptop")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := srv.ClientBuilder().WithAuditJournal(journal).Build()
+	for i := range n {
+		if _, err = client.CreateFolder(fmt.Sprintf("Folder %d", i), []int{group.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = journal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Split(bytes.TrimSuffix(data, []byte("\n")), []byte("\n"))
+}
+
+// rehash rewrites the chain from line i on as someone with write access to the journal could.
+func rehash(t *testing.T, lines [][]byte, i int, change func(*otlh.AuditEntry)) [][]byte {
+	t.Helper()
+
+	lines = cloneLines(lines)
+
+	var prevHash string
+	if i > 0 {
+		var prev otlh.AuditEntry
+		if err := json.Unmarshal(lines[i-1], &prev); err != nil {
+			t.Fatal(err)
+		}
+		prevHash = prev.Hash
+	}
+
+	for ; i < len(lines); i++ {
+		var entry otlh.AuditEntry
+		if err := json.Unmarshal(lines[i], &entry); err != nil {
+			t.Fatal(err)
+		}
+		if change != nil {
+			change(&entry)
+			change = nil
+		}
+
+		entry.PrevHash = prevHash
+		entry.Hash = ""
+		data, _ := json.Marshal(entry)
+		sum := sha256.Sum256(data)
+		entry.Hash = hex.EncodeToString(sum[:])
+
+		lines[i], _ = json.Marshal(entry)
+		prevHash = entry.Hash
+	}
+	return lines
+}
+
+func cloneLines(lines [][]byte) [][]byte {
+	clone := make([][]byte, len(lines))
+	for i, line := range lines {
+		clone[i] = bytes.Clone(line)
+	}
+	return clone
+}
+
+// replaceInLine returns a copy of lines with old replaced by new in line i.
+func replaceInLine(lines [][]byte, i int, old string, new string) [][]byte {
+	lines = cloneLines(lines)
+	lines[i] = bytes.Replace(lines[i], []byte(old), []byte(new), 1)
+	return lines
+}
+
+func headOf(t *testing.T, line []byte) otlh.AuditHead {
+	t.Helper()
+
+	var entry otlh.AuditEntry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		t.Fatal(err)
+	}
+	return otlh.AuditHead{Seq: entry.Seq, Hash: entry.Hash}
+}
+
+func TestVerifyAuditJournal(t *testing.T) {
+	lines := recordAuditJournal(t, 5)
+	if len(lines) != 5 {
+		t.Fatalf("got %d journal lines, want 5", len(lines))
+	}
+
+	head := headOf(t, lines[4])
+	earlier := headOf(t, lines[2])
+	rewritten := rehash(t, lines, 1, func(e *otlh.AuditEntry) { e.Operator = "mallory" })
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		lines    [][]byte
+		anchors  []otlh.AuditHead
+		wantSeq  int
+		wantErr  string
+		wantHead otlh.AuditHead
+	}{
+		{name: "unchanged", lines: lines, wantHead: head},
+		{name: "unchanged with anchors", lines: lines, anchors: []otlh.AuditHead{earlier, head}, wantHead: head},
+		{
+			name:    "modified entry",
+			lines:   replaceInLine(lines, 1, `"operator":"alice@laptop"`, `"operator":"mallory"`),
+			wantSeq: 1,
+			wantErr: "line 2: hash mismatch",
+		},
+		{
+			name:    "removed entry",
+			lines:   append(cloneLines(lines[:2]), lines[3:]...),
+			wantSeq: 2,
+			wantErr: "line 3: sequence 4, expected 3",
+		},
+		{
+			name:    "unknown field",
+			lines:   replaceInLine(lines, 3, `{`, `{"note":"x",`),
+			wantSeq: 3,
+			wantErr: "line 4: entry is not in canonical form",
+		},
+		// the two cases the chain alone can not catch
+		{name: "truncated", lines: lines[:3], wantHead: earlier},
+		{name: "rewritten", lines: rewritten, wantHead: headOf(t, rewritten[4])},
+		{
+			name:    "truncated before anchor",
+			lines:   lines[:3],
+			anchors: []otlh.AuditHead{head},
+			wantSeq: 3,
+			wantErr: "journal ends at sequence 3 before anchor 5:",
+		},
+		{
+			name:    "rewritten after anchor",
+			lines:   rewritten,
+			anchors: []otlh.AuditHead{earlier},
+			wantSeq: 2,
+			wantErr: "line 3: hash differs from anchor 3:",
+		},
+		{
+			name:    "rewritten and truncated",
+			lines:   rewritten[:4],
+			anchors: []otlh.AuditHead{head},
+			wantSeq: 4,
+			wantErr: "before anchor 5:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "_")+".jsonl")
+			if err := os.WriteFile(path, append(bytes.Join(tt.lines, []byte("\n")), '\n'), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := otlh.VerifyAuditJournal(path, tt.anchors...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				if got.Seq != tt.wantSeq {
+					t.Fatalf("got %d valid entries, want %d", got.Seq, tt.wantSeq)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.wantHead {
+				t.Fatalf("got head %s, want %s", got, tt.wantHead)
+			}
+		})
+	}
+}
+
+func TestParseAuditHead(t *testing.T) {
+	hash := strings.Repeat("ab", sha256.Size)
+
+	tests := []struct {
+		in      string
+		want    otlh.AuditHead
+		wantErr bool
+	}{
+		{in: "12:" + hash, want: otlh.AuditHead{Seq: 12, Hash: hash}},
+		{in: " 1:" + hash + "\n", want: otlh.AuditHead{Seq: 1, Hash: hash}},
+		{in: hash, wantErr: true},
+		{in: "0:" + hash, wantErr: true},
+		{in: "x:" + hash, wantErr: true},
+		{in: "12:" + hash[:10], wantErr: true},
+		{in: "12:" + strings.Repeat("zz", sha256.Size), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := otlh.ParseAuditHead(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			if err == nil && got.String() != strings.TrimSpace(tt.in) {
+				t.Fatalf("got String %q, want %q", got.String(), strings.TrimSpace(tt.in))
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package otlh
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+)
+
+const REDACTED = "REDACTED"
+
+// redactedHeaders are never written to a cassette.
+var redactedHeaders = []string{"X-AUTH-TOKEN", "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+/*
+Cassette is a recorded session with the API. The file holds a JSON header
+with RecordedAt followed by one JSON interaction per line, so a recording is
+appended to as it goes. Bodies that are not valid UTF-8 (e.g. uploaded zip
+packages) are stored base64 encoded.
+*/
+type Cassette struct {
+	RecordedAt   time.Time     `json:"recorded_at"`
+	Interactions []Interaction `json:"interactions,omitempty"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+/*
+LoadCassette reads the cassette at path. A last interaction cut short, e.g.
+by a crash while recording, is dropped with a warning.
+*/
+func LoadCassette(path string) (*Cassette, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+
+	// the header may also hold the interactions, as in cassettes written as a single document
+	var cassette Cassette
+	if err = dec.Decode(&cassette); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+
+	for {
+		var interaction Interaction
+		err = dec.Decode(&interaction)
+		if err == io.EOF {
+			break
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			log.Warn().Msgf("cassette %s: dropped incomplete last interaction", path)
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+		}
+		cassette.Interactions = append(cassette.Interactions, interaction)
+	}
+
+	return &cassette, nil
+}
+
+// Save writes the cassette to path, replacing an existing file only once it is completely written.
+func (c *Cassette) Save(path string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err = c.write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (c *Cassette) write(f *os.File) error {
+	enc := json.NewEncoder(f)
+	if err := enc.Encode(Cassette{RecordedAt: c.RecordedAt}); err != nil {
+		return err
+	}
+	for _, interaction := range c.Interactions {
+		if err := enc.Encode(interaction); err != nil {
+			return err
+		}
+	}
+	return f.Sync()
+}
+
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return "", base64.StdEncoding.EncodeToString(body)
+}
+
+func decodeBody(body string, b64 string) []byte {
+	if b64 == "" {
+		return []byte(body)
+	}
+	data, _ := base64.StdEncoding.DecodeString(b64)
+	return data
+}
+
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, k := range redactedHeaders {
+		if h.Get(k) != "" {
+			h.Set(k, REDACTED)
+		}
+	}
+	return h
+}
+
+// matchKey identifies a request on replay: method, path and sorted query.
+func matchKey(method string, u *url.URL) string {
+	return method + " " + u.Path + "?" + u.Query().Encode()
+}
+
+/*
+recorder is a RoundTripper appending every interaction to a temporary file
+next to the cassette file. Close renames it to the cassette file, so an
+existing cassette is only replaced by a complete recording; after a crash the
+temporary file still holds the interactions recorded so far.
+*/
+type recorder struct {
+	base http.RoundTripper
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+func newRecorder(base http.RoundTripper, path string) (*recorder, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+
+	rec := &recorder{base: base, path: path, file: f, enc: json.NewEncoder(f)}
+	if err = rec.enc.Encode(Cassette{RecordedAt: time.Now().UTC()}); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+
+	return rec, nil
+}
+
+// Close ends the recording and moves it to the cassette file.
+func (rec *recorder) Close() error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if rec.file == nil {
+		return nil
+	}
+
+	f := rec.file
+	rec.file = nil
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("cassette %s: %w", rec.path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("cassette %s: %w", rec.path, err)
+	}
+	if err := os.Rename(f.Name(), rec.path); err != nil {
+		return fmt.Errorf("cassette %s: %w", rec.path, err)
+	}
+
+	log.Debug().Msgf("saved cassette %s", rec.path)
+	return nil
+}
+
+func (rec *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := rec.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: redactHeader(req.Header),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header),
+		},
+	}
+	interaction.Request.Body, interaction.Request.BodyBase64 = encodeBody(reqBody)
+	interaction.Response.Body, interaction.Response.BodyBase64 = encodeBody(respBody)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if rec.file == nil {
+		log.Error().Msgf("cassette %s is closed, interaction not recorded", rec.path)
+		return resp, nil
+	}
+
+	// one line per interaction, an aborted session keeps what was recorded
+	if err := rec.enc.Encode(interaction); err != nil {
+		log.Error().Msgf("failed to record to cassette %s: %s", rec.path, err)
+	}
+
+	return resp, nil
+}
+
+/*
+replayer is a RoundTripper answering requests from a cassette file without
+any network access. Interactions are matched on method, path and query and
+consumed in the order they were recorded, so repeated requests (e.g. polling
+the same page) replay their recorded sequence.
+*/
+type replayer struct {
+	path string
+
+	once     sync.Once
+	err      error
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+func newReplayer(path string) *replayer {
+	return &replayer{path: path}
+}
+
+func (rp *replayer) load() error {
+	rp.once.Do(func() {
+		rp.cassette, rp.err = LoadCassette(rp.path)
+		if rp.err == nil {
+			rp.used = make([]bool, len(rp.cassette.Interactions))
+		}
+	})
+	return rp.err
+}
+
+func (rp *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := rp.load(); err != nil {
+		return nil, err
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	key := matchKey(req.Method, req.URL)
+
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	for i, interaction := range rp.cassette.Interactions {
+		if rp.used[i] {
+			continue
+		}
+
+		u, err := url.Parse(interaction.Request.URL)
+		if err != nil || matchKey(interaction.Request.Method, u) != key {
+			continue
+		}
+
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+
+		rp.used[i] = true
+		return &http.Response{
+			StatusCode:    interaction.Response.StatusCode,
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(decodeBody(interaction.Response.Body, interaction.Response.BodyBase64))),
+			ContentLength: -1,
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette %s: no recorded interaction left for %s", rp.path, key)
+}
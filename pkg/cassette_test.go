@@ -0,0 +1,172 @@
+package otlh_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+// recordFolders records a GetFolders call to path and returns the client, not closed yet.
+func recordFolders(t *testing.T, srv *otlhtest.Server, path string) *otlh.Client {
+	t.Helper()
+
+	client := srv.ClientBuilder().WithRecorder(path).Build()
+	if err := client.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Folder().Build()
+	if _, err := client.GetFolders(req); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+	srv.AddFolder(otlh.Folder{Name: "Acme"})
+
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	if err := recordFolders(t, srv, path).Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), otlhtest.AUTH_TOKEN) {
+		t.Fatal("the auth token was recorded")
+	}
+
+	replay := otlh.NewClientBuilder().
+		WithDomain("replay.invalid").
+		WithTenant("demo").
+		WithRetryPolicy(otlh.RetryPolicy{MaxAttempts: 1}).
+		WithReplayer(path).
+		Build()
+	req, _ := otlh.NewRequest().WithTenant("demo").Get().Folder().Build()
+
+	folders, err := replay.GetFolders(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(folders) != 1 || folders[0].Name != "Acme" {
+		t.Fatalf("got folders %+v, want the recorded Acme", folders)
+	}
+
+	// every interaction is replayed once
+	if _, err = replay.GetFolders(req); err == nil {
+		t.Fatal("got a second replay of a single recorded interaction")
+	}
+}
+
+func TestCassetteKeepsExistingFileUntilClosed(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.json")
+
+	good := &otlh.Cassette{RecordedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	if err := good.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// a recording that is never closed, as after a crash
+	recordFolders(t, srv, path)
+
+	cassette, err := otlh.LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cassette.RecordedAt.Equal(good.RecordedAt) || len(cassette.Interactions) != 0 {
+		t.Fatalf("the existing cassette was changed: %+v", cassette)
+	}
+
+	partial, _ := filepath.Glob(filepath.Join(dir, "session.json.*.tmp"))
+	if len(partial) != 1 {
+		t.Fatalf("got temporary files %v, want one", partial)
+	}
+	if cassette, err = otlh.LoadCassette(partial[0]); err != nil {
+		t.Fatal(err)
+	}
+	if len(cassette.Interactions) != 1 {
+		t.Fatalf("got %d interactions in the partial recording, want 1", len(cassette.Interactions))
+	}
+}
+
+func TestLoadCassette(t *testing.T) {
+	dir := t.TempDir()
+
+	interaction := `{"request":{"method":"GET","url":"https://x/t/demo/api/v3/folders"},"response":{"status_code":200,"body":"{}"}}`
+
+	tests := []struct {
+		name    string
+		content string
+		want    int
+		wantErr bool
+	}{
+		{name: "lines", content: `{"recorded_at":"2026-01-02T03:04:05Z"}` + "\n" + interaction + "\n" + interaction + "\n", want: 2},
+		{name: "single document", content: `{"recorded_at":"2026-01-02T03:04:05Z","interactions":[` + interaction + `]}`, want: 1},
+		{name: "cut short", content: `{"recorded_at":"2026-01-02T03:04:05Z"}` + "\n" + interaction + "\n" + interaction[:40], want: 1},
+		{name: "not json", content: "GET /folders", wantErr: true},
+		{name: "garbage line", content: `{"recorded_at":"2026-01-02T03:04:05Z"}` + "\n" + "]\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "_")+".json")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			cassette, err := otlh.LoadCassette(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && len(cassette.Interactions) != tt.want {
+				t.Fatalf("got %d interactions, want %d", len(cassette.Interactions), tt.want)
+			}
+		})
+	}
+}
+
+func TestCassetteSaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	want := &otlh.Cassette{RecordedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	for i := range 3 {
+		var interaction otlh.Interaction
+		interaction.Request.Method = "POST"
+		interaction.Request.URL = "https://x/t/demo/api/v3/legal_holds/import"
+		interaction.Request.BodyBase64 = "UEsDBA=="
+		interaction.Response.StatusCode = 200 + i
+		want.Interactions = append(want.Interactions, interaction)
+	}
+
+	if err := want.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := otlh.LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got.Interactions) != len(want.Interactions) {
+		t.Fatalf("got %d interactions, want %d", len(got.Interactions), len(want.Interactions))
+	}
+	for i := range want.Interactions {
+		if got.Interactions[i].Response.StatusCode != want.Interactions[i].Response.StatusCode ||
+			got.Interactions[i].Request.BodyBase64 != want.Interactions[i].Request.BodyBase64 {
+			t.Fatalf("interaction %d: got %+v, want %+v", i, got.Interactions[i], want.Interactions[i])
+		}
+	}
+}
@@ -1,9 +1,14 @@
 package otlh
 
 import (
-	"crypto/tls"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"slices"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -37,6 +42,24 @@ type Client struct {
 	// certificate.
 	skipVerify bool
 
+	// caCertFile adds the PEM encoded CA certificates of the file to the
+	// system roots, e.g. the CA of a TLS intercepting proxy.
+	caCertFile string
+
+	// clientCertFile and clientKeyFile hold the PEM encoded certificate
+	// presented to servers requiring mutual TLS.
+	clientCertFile string
+	clientKeyFile  string
+
+	// minTLSVersion is the lowest TLS version accepted, TLS 1.2 by default.
+	minTLSVersion uint16
+
+	// err is an invalid setting found by Build, returned by every request.
+	err error
+
+	// auditJournal records every mutating request, nil disables auditing.
+	auditJournal *AuditJournal
+
 	// domain is the domain to connect to.
 	domain string
 
@@ -51,17 +74,49 @@ type Client struct {
 	// AuthToken is the auth token to use when connecting to the API.
 	authToken string
 
+	// tokenSource supplies the auth token of each request, a StaticToken of
+	// authToken unless set with WithTokenSource.
+	tokenSource TokenSource
+
+	// retryPolicy controls how failed requests are retried by Send.
+	retryPolicy RetryPolicy
+
+	// limiter throttles every request sent by Send, an unlimited one backing
+	// off on 429 responses unless set with WithRateLimit or WithRateLimiter.
+	// nil means unlimited.
+	limiter *RateLimiter
+
+	// recordPath and replayPath name the cassette file used to record or
+	// replay the session, see WithRecorder and WithReplayer.
+	recordPath string
+	replayPath string
+
+	// recorder is the transport recording to recordPath, closed by Close.
+	recorder *recorder
+
+	// pageConcurrency is the number of pages the GetAll* and Iter* methods fetch at once.
+	pageConcurrency int
+
+	// redactor masks credentials, and optionally PII, in the trace output.
+	redactor *Redactor
+
 	// RestyClient is the resty client used to perform requests.
 	RestyClient *resty.Client
 }
 
 func NewClientBuilder() *ClientBuilder {
 	return &ClientBuilder{&Client{
-		skipVerify: false,
-		domain:     "localhost",
-		port:       DEFAULT_PORT,
-		httpProxy:  "",
-		authToken:  "",
+		skipVerify:  false,
+		domain:      "localhost",
+		port:        DEFAULT_PORT,
+		httpProxy:   "",
+		authToken:   "",
+		retryPolicy: DefaultRetryPolicy(),
+		limiter:     NewRateLimiter(0, 1),
+
+		pageConcurrency: DEFAULT_PAGE_CONCURRENCY,
+		redactor:        NewRedactor(),
+		minTLSVersion:   DEFAULT_MIN_TLS_VERSION,
 	}}
 }
 
@@ -85,16 +140,126 @@ func (b *ClientBuilder) WithAuthToken(authToken string) *ClientBuilder {
 	return b
 }
 
+/*
+WithTokenSource fetches the auth token from ts before every request instead of
+using a fixed one, see FileTokenSource and CommandTokenSource. It takes
+precedence over WithAuthToken.
+*/
+func (b *ClientBuilder) WithTokenSource(ts TokenSource) *ClientBuilder {
+	b.tokenSource = ts
+	return b
+}
+
 func (b *ClientBuilder) WithHttpProxy(proxy string) *ClientBuilder {
 	b.httpProxy = proxy
 	return b
 }
 
+func (b *ClientBuilder) WithRetryPolicy(policy RetryPolicy) *ClientBuilder {
+	b.retryPolicy = policy
+	return b
+}
+
+/*
+WithRateLimit limits the client to rps requests per second with bursts of up
+to burst requests. A rate of 0 or less sets no limit, the client still backs
+off when the server answers 429 Too Many Requests.
+*/
+func (b *ClientBuilder) WithRateLimit(rps float64, burst int) *ClientBuilder {
+	b.limiter = NewRateLimiter(rps, burst)
+	return b
+}
+
+/*
+WithRateLimiter shares an existing limiter, e.g. between clients of the same
+tenant. A nil limiter never throttles, not even on 429 responses.
+*/
+func (b *ClientBuilder) WithRateLimiter(limiter *RateLimiter) *ClientBuilder {
+	b.limiter = limiter
+	return b
+}
+
+/*
+WithPageConcurrency sets how many pages the GetAll* and Iter* methods fetch at once
+after the first one, 1 fetches them one after another.
+*/
+func (b *ClientBuilder) WithPageConcurrency(n int) *ClientBuilder {
+	b.pageConcurrency = max(1, n)
+	return b
+}
+
+/*
+WithAuditJournal records every POST, PATCH, PUT and DELETE request sent by the
+client, and its outcome, in journal. See OpenAuditJournal.
+*/
+func (b *ClientBuilder) WithAuditJournal(journal *AuditJournal) *ClientBuilder {
+	b.auditJournal = journal
+	return b
+}
+
+/*
+WithRecorder saves every request/response pair sent by the client to the
+cassette file at path, with credentials redacted. The recording replaces the
+file when the client is closed, see Client.Close.
+*/
+func (b *ClientBuilder) WithRecorder(path string) *ClientBuilder {
+	b.recordPath = path
+	return b
+}
+
+/*
+WithReplayer answers every request from the cassette file at path, recorded
+earlier with WithRecorder, instead of contacting the server.
+*/
+func (b *ClientBuilder) WithReplayer(path string) *ClientBuilder {
+	b.replayPath = path
+	return b
+}
+
+/*
+WithRedactor sets the Redactor masking the trace output of the client, one of
+its own when not set. Build registers the auth token and the proxy password
+with it, so clients sharing a Redactor mask each other's secrets too.
+*/
+func (b *ClientBuilder) WithRedactor(r *Redactor) *ClientBuilder {
+	b.redactor = r
+	return b
+}
+
+// SkipVerify disables verifying the server's TLS certificate, prefer WithCACertFile.
 func (b *ClientBuilder) SkipVerify() *ClientBuilder {
 	b.skipVerify = true
 	return b
 }
 
+// WithSkipVerify is like SkipVerify but can also turn verification back on.
+func (b *ClientBuilder) WithSkipVerify(skip bool) *ClientBuilder {
+	b.skipVerify = skip
+	return b
+}
+
+/*
+WithCACertFile trusts the PEM encoded CA certificates in path in addition to
+the system roots, e.g. the CA of a corporate proxy re-signing TLS traffic.
+*/
+func (b *ClientBuilder) WithCACertFile(path string) *ClientBuilder {
+	b.caCertFile = path
+	return b
+}
+
+// WithClientCertificate presents the PEM encoded certificate and key to servers requiring mutual TLS.
+func (b *ClientBuilder) WithClientCertificate(certFile, keyFile string) *ClientBuilder {
+	b.clientCertFile = certFile
+	b.clientKeyFile = keyFile
+	return b
+}
+
+// WithMinTLSVersion sets the lowest TLS version accepted, e.g. tls.VersionTLS13, see ParseTLSVersion.
+func (b *ClientBuilder) WithMinTLSVersion(version uint16) *ClientBuilder {
+	b.minTLSVersion = version
+	return b
+}
+
 /*
 Build constructs a new OpenText Legal Hold API client from a ClientBuilder.
 
@@ -107,13 +272,17 @@ Returns:
 This function builds a resty client with the base URL and headers set and then
 sets the RestyClient field on the ClientBuilder. It returns the Client field
 of the ClientBuilder.
+
+An invalid TLS setting, e.g. an unreadable CA certificate file, or a cassette
+file that can not be created is reported by Client.Err, check it right after
+Build. It is also returned by every
+request sent with the client.
 */
 func (b *ClientBuilder) Build() *Client {
 	r := resty.New().
 		SetBaseURL(fmt.Sprintf("https://%s:%d", b.domain, b.port)).
 		SetHeaders(map[string]string{
 			"accept":       "application/json",
-			"X-AUTH-TOKEN": b.authToken,
 			"Content-Type": "application/json",
 		})
 
@@ -121,12 +290,44 @@ func (b *ClientBuilder) Build() *Client {
 		r.SetProxy(b.httpProxy)
 	}
 
+	if b.tokenSource == nil {
+		b.tokenSource = StaticToken(b.authToken)
+	}
+
+	b.redactor.AddSecret(b.authToken)
+	if u, err := url.Parse(b.httpProxy); err == nil && u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			b.redactor.AddSecret(password)
+		}
+	}
+
+	// trace output goes through zerolog with credentials masked
+	r.SetLogger(restyLogger{}).
+		OnRequestLog(b.redactor.redactRequestLog).
+		OnResponseLog(b.redactor.redactResponseLog)
+
 	if zerolog.GlobalLevel() == zerolog.TraceLevel {
 		r.SetDebug(true)
 	}
 
-	if b.skipVerify {
-		r.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
+	if tlsConfig, err := b.tlsConfig(); err != nil {
+		b.err = err
+	} else {
+		r.SetTLSClientConfig(tlsConfig)
+	}
+
+	// wrap the transport last so it sees the proxy and TLS settings above
+	switch {
+	case b.replayPath != "":
+		r.SetTransport(newReplayer(b.replayPath))
+	case b.recordPath != "":
+		rec, err := newRecorder(r.GetClient().Transport, b.recordPath)
+		if err != nil {
+			b.err = errors.Join(b.err, err)
+			break
+		}
+		b.recorder = rec
+		r.SetTransport(rec)
 	}
 
 	b.RestyClient = r
@@ -134,6 +335,22 @@ func (b *ClientBuilder) Build() *Client {
 	return b.Client
 }
 
+// Err returns the error of an invalid setting found by Build, nil if the client is usable.
+func (c *Client) Err() error {
+	return c.err
+}
+
+/*
+Close releases what Build set up: with WithRecorder, it saves the recording
+to the cassette file. The client must not be used afterwards.
+*/
+func (c *Client) Close() error {
+	if c.recorder == nil {
+		return nil
+	}
+	return c.recorder.Close()
+}
+
 func handleOptions(r *resty.Request, opts ...Options) (bool, error) {
 	var isMultipart bool
 	for _, opt := range opts {
@@ -154,6 +371,16 @@ func (c *Client) Tenant() string {
 	return c.tenant
 }
 
+// Redactor returns the redactor masking the trace output of the client.
+func (c *Client) Redactor() *Redactor {
+	return c.redactor
+}
+
+// RateLimiter returns the limiter of the client, e.g. to share it with another client.
+func (c *Client) RateLimiter() *RateLimiter {
+	return c.limiter
+}
+
 /*
 Send sends a request to the server and returns the response body.
 
@@ -164,12 +391,101 @@ Parameters:
 Returns:
 - []byte: The response body
 - error: Any error that occurred during the request
+
+Failed attempts are retried according to the client's RetryPolicy. A request
+rejected with 401 is sent once more with a new token when the client's
+TokenSource is a RefreshableTokenSource. POST, PATCH, PUT and DELETE requests
+are recorded in the audit journal set with WithAuditJournal.
 */
 func (c *Client) Send(req Requestor, opts ...Options) ([]byte, error) {
+	return c.SendContext(context.Background(), req, opts...)
+}
+
+// SendContext is like Send but aborts the request, and any pending retry,
+// when ctx is done.
+func (c *Client) SendContext(ctx context.Context, req Requestor, opts ...Options) ([]byte, error) {
 	var resp *resty.Response
 	var err error
+	var refreshed bool
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	for attempt := 1; ; attempt++ {
+		if err = c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		// token errors are not retried, the next attempt would fail the same way
+		var token string
+		if token, err = c.tokenSource.Token(ctx); err != nil {
+			return nil, err
+		}
+		c.redactor.AddSecret(token)
+
+		resp, err = c.execute(ctx, req, token, opts...)
+		if ctx.Err() != nil {
+			// the server may have processed the request before it was aborted
+			c.audit(req, opts, resp, ctx.Err())
+			return nil, ctx.Err()
+		}
+
+		// a rejected token may have expired, get a new one and try once more
+		if err == nil && resp.StatusCode() == http.StatusUnauthorized && !refreshed {
+			if ts, ok := c.tokenSource.(RefreshableTokenSource); ok {
+				log.Debug().Msgf("%s returned status 401, refreshing auth token", req.Endpoint())
+				ts.Invalidate()
+				refreshed = true
+				attempt--
+				continue
+			}
+		}
+
+		if err == nil {
+			if resp.StatusCode() == http.StatusTooManyRequests {
+				retryAfter, _ := c.retryPolicy.retryAfter(resp)
+				c.limiter.throttle(retryAfter)
+			} else if resp.IsSuccess() {
+				c.limiter.relax()
+			}
+		}
 
-	r := c.RestyClient.R()
+		retry, wait := c.retryPolicy.shouldRetry(req.Method(), attempt, resp, err)
+		if !retry {
+			break
+		}
+
+		if err != nil {
+			log.Debug().Msgf("attempt %d of %s failed: %s, retrying in %s", attempt, req.Endpoint(), err, wait)
+		} else {
+			log.Debug().Msgf("attempt %d of %s returned status %d, retrying in %s", attempt, req.Endpoint(), resp.StatusCode(), wait)
+		}
+		select {
+		case <-ctx.Done():
+			c.audit(req, opts, resp, ctx.Err())
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+
+	c.audit(req, opts, resp, err)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Return an error if the status code is not 2xx, e.g. 204 for a DELETE.
+	if !resp.IsSuccess() {
+		return nil, newAPIError(req, resp)
+	}
+
+	return resp.Body(), nil
+}
+
+// execute performs a single attempt of req authenticated with token.
+func (c *Client) execute(ctx context.Context, req Requestor, token string, opts ...Options) (*resty.Response, error) {
+	r := c.RestyClient.R().SetContext(ctx).SetHeader("X-AUTH-TOKEN", token)
 
 	isMultipart, _ := handleOptions(r, opts...)
 	if isMultipart {
@@ -180,25 +496,18 @@ func (c *Client) Send(req Requestor, opts ...Options) ([]byte, error) {
 
 	switch req.Method() {
 	case GET:
-		resp, err = r.Get(req.Endpoint())
+		return r.Get(req.Endpoint())
 	case POST:
-		resp, err = r.Post(req.Endpoint())
+		return r.Post(req.Endpoint())
 	case PATCH:
-		resp, err = r.Patch(req.Endpoint())
-	default:
-		return nil, fmt.Errorf("unsupported method")
+		return r.Patch(req.Endpoint())
+	case PUT:
+		return r.Put(req.Endpoint())
+	case DELETE:
+		return r.Delete(req.Endpoint())
 	}
 
-	if err != nil {
-		return nil, err
-	}
-
-	// Return an error if the status code is not 200.
-	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode())
-	}
-
-	return resp.Body(), nil
+	return nil, fmt.Errorf("unsupported method")
 }
 
 /*
@@ -213,10 +522,15 @@ Returns:
 - error: Any error that occurred during the request or unmarshalling
 */
 func (c *Client) Do(req Requestor, resource any, opts ...Options) error {
+	return c.DoContext(context.Background(), req, resource, opts...)
+}
+
+// DoContext is like Do but carries ctx.
+func (c *Client) DoContext(ctx context.Context, req Requestor, resource any, opts ...Options) error {
 	var v []byte
 	var err error
 
-	if v, err = c.Send(req, opts...); err != nil {
+	if v, err = c.SendContext(ctx, req, opts...); err != nil {
 		return err
 	}
 
@@ -224,121 +538,290 @@ func (c *Client) Do(req Requestor, resource any, opts ...Options) error {
 }
 
 func (c *Client) GetCustodian(req Requestor) (Custodian, error) {
+	return c.GetCustodianContext(context.Background(), req)
+}
+
+func (c *Client) GetCustodianContext(ctx context.Context, req Requestor) (Custodian, error) {
 	var custodian Custodian
-	return custodian, c.Do(req, &custodian)
+	return custodian, c.DoContext(ctx, req, &custodian)
 }
 
 func (c *Client) GetCustodians(req Requestor, opts ...Options) (Custodians, error) {
+	return c.GetCustodiansContext(context.Background(), req, opts...)
+}
+
+func (c *Client) GetCustodiansContext(ctx context.Context, req Requestor, opts ...Options) (Custodians, error) {
 	var resp CustodiansResponse
-	return resp.Embedded.Custodians, c.Do(req, &resp, opts...)
+	return resp.Embedded.Custodians, c.DoContext(ctx, req, &resp, opts...)
 }
 
 func (c *Client) GetAllCustodians(req Requestor, opts Options) (Custodians, error) {
-	return getAllEntities(c, req, opts, unmarshalCustodians)
+	return c.GetAllCustodiansContext(context.Background(), req, opts)
+}
+
+func (c *Client) GetAllCustodiansContext(ctx context.Context, req Requestor, opts Options) (Custodians, error) {
+	return getAllEntities(ctx, c, req, opts, unmarshalCustodians)
+}
+
+/*
+IterCustodians ranges over every custodian of req, fetching up to
+pageConcurrency pages ahead of the caller. The other Iter* methods work the
+same way, see iterEntities.
+*/
+func (c *Client) IterCustodians(req Requestor, opts Options) iter.Seq2[Custodian, error] {
+	return c.IterCustodiansContext(context.Background(), req, opts)
+}
+
+func (c *Client) IterCustodiansContext(ctx context.Context, req Requestor, opts Options) iter.Seq2[Custodian, error] {
+	return iterEntities(ctx, c, req, opts, unmarshalCustodians)
 }
 
 func (c *Client) GetCustodianGroup(req Requestor) (CustodianGroup, error) {
+	return c.GetCustodianGroupContext(context.Background(), req)
+}
+
+func (c *Client) GetCustodianGroupContext(ctx context.Context, req Requestor) (CustodianGroup, error) {
 	var custodianGroup CustodianGroup
-	return custodianGroup, c.Do(req, &custodianGroup)
+	return custodianGroup, c.DoContext(ctx, req, &custodianGroup)
 }
 
 func (c *Client) GetCustodianGroups(req Requestor, opts ...Options) (CustodianGroups, error) {
+	return c.GetCustodianGroupsContext(context.Background(), req, opts...)
+}
+
+func (c *Client) GetCustodianGroupsContext(ctx context.Context, req Requestor, opts ...Options) (CustodianGroups, error) {
 	var resp CustodianGroupsResponse
-	return resp.Embedded.CustodianGroups, c.Do(req, &resp, opts...)
+	return resp.Embedded.CustodianGroups, c.DoContext(ctx, req, &resp, opts...)
 }
 
 func (c *Client) GetAllCustodianGroups(req Requestor, opts Options) (CustodianGroups, error) {
-	return getAllEntities(c, req, opts, unmarshalCustodianGroups)
+	return c.GetAllCustodianGroupsContext(context.Background(), req, opts)
+}
+
+func (c *Client) GetAllCustodianGroupsContext(ctx context.Context, req Requestor, opts Options) (CustodianGroups, error) {
+	return getAllEntities(ctx, c, req, opts, unmarshalCustodianGroups)
+}
+
+func (c *Client) IterCustodianGroups(req Requestor, opts Options) iter.Seq2[CustodianGroup, error] {
+	return c.IterCustodianGroupsContext(context.Background(), req, opts)
+}
+
+func (c *Client) IterCustodianGroupsContext(ctx context.Context, req Requestor, opts Options) iter.Seq2[CustodianGroup, error] {
+	return iterEntities(ctx, c, req, opts, unmarshalCustodianGroups)
 }
 
 func (c *Client) GetGroup(req Requestor) (Group, error) {
+	return c.GetGroupContext(context.Background(), req)
+}
+
+func (c *Client) GetGroupContext(ctx context.Context, req Requestor) (Group, error) {
 	var group Group
-	return group, c.Do(req, &group)
+	return group, c.DoContext(ctx, req, &group)
 }
 
 func (c *Client) GetGroups(req Requestor, opts ...Options) (Groups, error) {
+	return c.GetGroupsContext(context.Background(), req, opts...)
+}
+
+func (c *Client) GetGroupsContext(ctx context.Context, req Requestor, opts ...Options) (Groups, error) {
 	var resp GroupsResponse
-	return resp.Embedded.Groups, c.Do(req, &resp, opts...)
+	return resp.Embedded.Groups, c.DoContext(ctx, req, &resp, opts...)
 }
 
 func (c *Client) GetAllGroups(req Requestor, opts Options) (Groups, error) {
-	return getAllEntities(c, req, opts, unmarshalGroups)
+	return c.GetAllGroupsContext(context.Background(), req, opts)
+}
+
+func (c *Client) GetAllGroupsContext(ctx context.Context, req Requestor, opts Options) (Groups, error) {
+	return getAllEntities(ctx, c, req, opts, unmarshalGroups)
+}
+
+func (c *Client) IterGroups(req Requestor, opts Options) iter.Seq2[Group, error] {
+	return c.IterGroupsContext(context.Background(), req, opts)
+}
+
+func (c *Client) IterGroupsContext(ctx context.Context, req Requestor, opts Options) iter.Seq2[Group, error] {
+	return iterEntities(ctx, c, req, opts, unmarshalGroups)
 }
 
 func (c *Client) GetFolder(req Requestor) (Folder, error) {
+	return c.GetFolderContext(context.Background(), req)
+}
+
+func (c *Client) GetFolderContext(ctx context.Context, req Requestor) (Folder, error) {
 	var folder Folder
-	return folder, c.Do(req, &folder)
+	return folder, c.DoContext(ctx, req, &folder)
 }
 
 func (c *Client) GetFolders(req Requestor, opts ...Options) (Folders, error) {
+	return c.GetFoldersContext(context.Background(), req, opts...)
+}
+
+func (c *Client) GetFoldersContext(ctx context.Context, req Requestor, opts ...Options) (Folders, error) {
 	var resp FoldersResponse
-	return resp.Embedded.Folders, c.Do(req, &resp, opts...)
+	return resp.Embedded.Folders, c.DoContext(ctx, req, &resp, opts...)
 }
 
 func (c *Client) GetAllFolders(req Requestor, opts Options) (Folders, error) {
-	return getAllEntities(c, req, opts, unmarshalFolders)
+	return c.GetAllFoldersContext(context.Background(), req, opts)
+}
+
+func (c *Client) GetAllFoldersContext(ctx context.Context, req Requestor, opts Options) (Folders, error) {
+	return getAllEntities(ctx, c, req, opts, unmarshalFolders)
+}
+
+func (c *Client) IterFolders(req Requestor, opts Options) iter.Seq2[Folder, error] {
+	return c.IterFoldersContext(context.Background(), req, opts)
+}
+
+func (c *Client) IterFoldersContext(ctx context.Context, req Requestor, opts Options) iter.Seq2[Folder, error] {
+	return iterEntities(ctx, c, req, opts, unmarshalFolders)
 }
 
 func (c *Client) GetMatter(req Requestor) (Matter, error) {
+	return c.GetMatterContext(context.Background(), req)
+}
+
+func (c *Client) GetMatterContext(ctx context.Context, req Requestor) (Matter, error) {
 	var matter Matter
-	return matter, c.Do(req, &matter)
+	return matter, c.DoContext(ctx, req, &matter)
 }
 
 func (c *Client) GetMatters(req Requestor, opts ...Options) (Matters, error) {
+	return c.GetMattersContext(context.Background(), req, opts...)
+}
+
+func (c *Client) GetMattersContext(ctx context.Context, req Requestor, opts ...Options) (Matters, error) {
 	var resp MattersResponse
-	return resp.Embedded.Matters, c.Do(req, &resp, opts...)
+	return resp.Embedded.Matters, c.DoContext(ctx, req, &resp, opts...)
 }
 
 func (c *Client) GetAllMatters(req Requestor, opts Options) (Matters, error) {
-	return getAllEntities(c, req, opts, unmarshalMatters)
+	return c.GetAllMattersContext(context.Background(), req, opts)
+}
+
+func (c *Client) GetAllMattersContext(ctx context.Context, req Requestor, opts Options) (Matters, error) {
+	return getAllEntities(ctx, c, req, opts, unmarshalMatters)
+}
+
+func (c *Client) IterMatters(req Requestor, opts Options) iter.Seq2[Matter, error] {
+	return c.IterMattersContext(context.Background(), req, opts)
+}
+
+func (c *Client) IterMattersContext(ctx context.Context, req Requestor, opts Options) iter.Seq2[Matter, error] {
+	return iterEntities(ctx, c, req, opts, unmarshalMatters)
 }
 
 func (c *Client) GetLegalhold(req Requestor) (Legalhold, error) {
+	return c.GetLegalholdContext(context.Background(), req)
+}
+
+func (c *Client) GetLegalholdContext(ctx context.Context, req Requestor) (Legalhold, error) {
 	var legalhold Legalhold
-	return legalhold, c.Do(req, &legalhold)
+	return legalhold, c.DoContext(ctx, req, &legalhold)
 }
 
 func (c *Client) GetLegalholds(req Requestor, opts ...Options) (Legalholds, error) {
+	return c.GetLegalholdsContext(context.Background(), req, opts...)
+}
+
+func (c *Client) GetLegalholdsContext(ctx context.Context, req Requestor, opts ...Options) (Legalholds, error) {
 	var resp LegalholdsResponse
-	err := c.Do(req, &resp, opts...)
+	err := c.DoContext(ctx, req, &resp, opts...)
 	return resp.Embedded.Legalholds, err
 }
 
 func (c *Client) GetAllLegalholds(req Requestor, opts Options) (Legalholds, error) {
-	return getAllEntities(c, req, opts, unmarshalLegalholds)
+	return c.GetAllLegalholdsContext(context.Background(), req, opts)
+}
+
+func (c *Client) GetAllLegalholdsContext(ctx context.Context, req Requestor, opts Options) (Legalholds, error) {
+	return getAllEntities(ctx, c, req, opts, unmarshalLegalholds)
+}
+
+func (c *Client) IterLegalholds(req Requestor, opts Options) iter.Seq2[Legalhold, error] {
+	return c.IterLegalholdsContext(context.Background(), req, opts)
+}
+
+func (c *Client) IterLegalholdsContext(ctx context.Context, req Requestor, opts Options) iter.Seq2[Legalhold, error] {
+	return iterEntities(ctx, c, req, opts, unmarshalLegalholds)
 }
 
 func (c *Client) GetSilenthold(req Requestor) (Silenthold, error) {
+	return c.GetSilentholdContext(context.Background(), req)
+}
+
+func (c *Client) GetSilentholdContext(ctx context.Context, req Requestor) (Silenthold, error) {
 	var silenthold Silenthold
-	return silenthold, c.Do(req, &silenthold)
+	return silenthold, c.DoContext(ctx, req, &silenthold)
 }
 
 func (c *Client) GetSilentholds(req Requestor, opts ...Options) (Silentholds, error) {
+	return c.GetSilentholdsContext(context.Background(), req, opts...)
+}
+
+func (c *Client) GetSilentholdsContext(ctx context.Context, req Requestor, opts ...Options) (Silentholds, error) {
 	var resp SilentholdsResponse
-	err := c.Do(req, &resp, opts...)
+	err := c.DoContext(ctx, req, &resp, opts...)
 	return resp.Embedded.Silentholds, err
 }
 
 func (c *Client) GetAllSilentholds(req Requestor, opts Options) (Silentholds, error) {
-	return getAllEntities(c, req, opts, unmarshalSilentholds)
+	return c.GetAllSilentholdsContext(context.Background(), req, opts)
+}
+
+func (c *Client) GetAllSilentholdsContext(ctx context.Context, req Requestor, opts Options) (Silentholds, error) {
+	return getAllEntities(ctx, c, req, opts, unmarshalSilentholds)
+}
+
+func (c *Client) IterSilentholds(req Requestor, opts Options) iter.Seq2[Silenthold, error] {
+	return c.IterSilentholdsContext(context.Background(), req, opts)
+}
+
+func (c *Client) IterSilentholdsContext(ctx context.Context, req Requestor, opts Options) iter.Seq2[Silenthold, error] {
+	return iterEntities(ctx, c, req, opts, unmarshalSilentholds)
 }
 
 func (c *Client) GetQuestionnaire(req Requestor) (Questionnaire, error) {
+	return c.GetQuestionnaireContext(context.Background(), req)
+}
+
+func (c *Client) GetQuestionnaireContext(ctx context.Context, req Requestor) (Questionnaire, error) {
 	var questionnaire Questionnaire
-	return questionnaire, c.Do(req, &questionnaire)
+	return questionnaire, c.DoContext(ctx, req, &questionnaire)
 }
 
 func (c *Client) GetQuestionnaires(req Requestor, opts ...Options) (Questionnaires, error) {
+	return c.GetQuestionnairesContext(context.Background(), req, opts...)
+}
+
+func (c *Client) GetQuestionnairesContext(ctx context.Context, req Requestor, opts ...Options) (Questionnaires, error) {
 	var resp QuestionnairesResponse
-	err := c.Do(req, &resp, opts...)
+	err := c.DoContext(ctx, req, &resp, opts...)
 	return resp.Embedded.Questionnaires, err
 }
 
 func (c *Client) GetAllQuestionnaires(req Requestor, opts Options) (Questionnaires, error) {
-	return getAllEntities(c, req, opts, unmarshalQuestionnaires)
+	return c.GetAllQuestionnairesContext(context.Background(), req, opts)
+}
+
+func (c *Client) GetAllQuestionnairesContext(ctx context.Context, req Requestor, opts Options) (Questionnaires, error) {
+	return getAllEntities(ctx, c, req, opts, unmarshalQuestionnaires)
+}
+
+func (c *Client) IterQuestionnaires(req Requestor, opts Options) iter.Seq2[Questionnaire, error] {
+	return c.IterQuestionnairesContext(context.Background(), req, opts)
+}
+
+func (c *Client) IterQuestionnairesContext(ctx context.Context, req Requestor, opts Options) iter.Seq2[Questionnaire, error] {
+	return iterEntities(ctx, c, req, opts, unmarshalQuestionnaires)
 }
 
 func (c *Client) ImportCustodians(custodians []CustodianInputData, batchSize int) error {
+	return c.ImportCustodiansContext(context.Background(), custodians, batchSize)
+}
+
+func (c *Client) ImportCustodiansContext(ctx context.Context, custodians []CustodianInputData, batchSize int) error {
 	bar := progressbar.Default(int64(len(custodians)))
 	defer bar.Finish()
 
@@ -363,18 +846,21 @@ func (c *Client) ImportCustodians(custodians []CustodianInputData, batchSize int
 
 		opts := NewBodyOptions().WithBody(string(custodianBody))
 		var resp CustodiansSyncResponse
-		if err := c.Do(req, &resp, opts); err != nil {
-			return err
+		if err := c.DoContext(ctx, req, &resp, opts); err != nil {
+			return fmt.Errorf("imported %d of %d custodians: %w", i, len(custodians), err)
 		}
 
 		bar.Add(batchSize)
-		time.Sleep(5 * time.Millisecond)
 	}
 
 	return nil
 }
 
 func (c *Client) ImportMatter(matter ImportMatterBody) (Matter, error) {
+	return c.ImportMatterContext(context.Background(), matter)
+}
+
+func (c *Client) ImportMatterContext(ctx context.Context, matter ImportMatterBody) (Matter, error) {
 	var respBody []byte
 	var resp Matter
 
@@ -387,7 +873,7 @@ func (c *Client) ImportMatter(matter ImportMatterBody) (Matter, error) {
 
 	opts := NewBodyOptions().WithBody(string(matterBody))
 
-	if respBody, err = c.Send(req, opts); err != nil {
+	if respBody, err = c.SendContext(ctx, req, opts); err != nil {
 		return resp, err
 	}
 
@@ -419,6 +905,11 @@ func (c *Client) ImportMatter(matter ImportMatterBody) (Matter, error) {
  * as an error.
  */
 func (c *Client) ImportLegalhold(zipFile string) (Legalhold, error) {
+	return c.ImportLegalholdContext(context.Background(), zipFile)
+}
+
+// ImportLegalholdContext is like ImportLegalhold but carries ctx.
+func (c *Client) ImportLegalholdContext(ctx context.Context, zipFile string) (Legalhold, error) {
 	var err error
 
 	var respBody []byte
@@ -427,7 +918,7 @@ func (c *Client) ImportLegalhold(zipFile string) (Legalhold, error) {
 	req, _ := NewRequest().WithTenant(c.tenant).Post().Legalhold().Import().Build()
 	opts := NewFileOptions().WithFile("legal_hold_details", zipFile)
 
-	if respBody, err = c.Send(req, opts); err != nil {
+	if respBody, err = c.SendContext(ctx, req, opts); err != nil {
 		return resp, err
 	}
 
@@ -439,6 +930,10 @@ func (c *Client) ImportLegalhold(zipFile string) (Legalhold, error) {
 }
 
 func (c *Client) ImportSilenthold(zipFile string) (Silenthold, error) {
+	return c.ImportSilentholdContext(context.Background(), zipFile)
+}
+
+func (c *Client) ImportSilentholdContext(ctx context.Context, zipFile string) (Silenthold, error) {
 	var err error
 
 	var respBody []byte
@@ -447,7 +942,7 @@ func (c *Client) ImportSilenthold(zipFile string) (Silenthold, error) {
 	req, _ := NewRequest().WithTenant(c.tenant).Post().Silenthold().Import().Build()
 	opts := NewFileOptions().WithFile("silent_hold_details", zipFile)
 
-	if respBody, err = c.Send(req, opts); err != nil {
+	if respBody, err = c.SendContext(ctx, req, opts); err != nil {
 		return resp, err
 	}
 
@@ -468,34 +963,28 @@ func (c *Client) ImportSilenthold(zipFile string) (Silenthold, error) {
  * - Folder: the found folder, or an empty Folder if not found
  * - error: any error that occurred during the search
  *
- * This function first retrieves a list of folders using the GetFolders method with a filter
- * for the provided name. It then iterates through the list of folders and returns the first
- * one that matches the exact name. If no folder is found, an error is returned.
+ * This function pages through the folders filtered by the provided name, which the server
+ * matches as a substring, to the last page and keeps the folders with the exact name. If no folder is found,
+ * an error wrapping ErrNotFound is returned, if several are, one wrapping ErrAmbiguous.
  */
 func (c *Client) FindFolderByName(name string) (Folder, error) {
-	var err error
-	var folders Folders = Folders{}
+	return c.FindFolderByNameContext(context.Background(), name)
+}
 
+// FindFolderByNameContext is like FindFolderByName but carries ctx.
+func (c *Client) FindFolderByNameContext(ctx context.Context, name string) (Folder, error) {
 	log.Debug().Msgf("searching folder by name [%s]", name)
 
-	/*
-		filterName helps to limit the results to folders CONTAINING the provided name.
-	*/
 	req, _ := NewRequest().WithTenant(c.tenant).Get().Folder().Build()
-	opts := NewListOptions().WithFilterName(name)
 
-	if folders, err = c.GetFolders(req, opts); err != nil {
+	folders, err := findEntities(ctx, c, req, name, unmarshalFolders, func(folder Folder) bool {
+		return folder.Name == name
+	})
+	if err != nil {
 		return Folder{}, err
 	}
 
-	// find folder name matches exactly and return it
-	for _, folder := range folders {
-		if folder.Name == name {
-			return folder, nil
-		}
-	}
-
-	return Folder{}, fmt.Errorf("folder [%s] not found", name)
+	return ExactlyOne(folders, "folder", name, func(folder Folder) int { return folder.ID })
 }
 
 /**
@@ -514,6 +1003,11 @@ func (c *Client) FindFolderByName(name string) (Folder, error) {
  * is returned.
  */
 func (c *Client) CreateFolder(name string, groupIDs []int) (Folder, error) {
+	return c.CreateFolderContext(context.Background(), name, groupIDs)
+}
+
+// CreateFolderContext is like CreateFolder but carries ctx.
+func (c *Client) CreateFolderContext(ctx context.Context, name string, groupIDs []int) (Folder, error) {
 	var err error
 	var folder Folder = Folder{}
 
@@ -523,7 +1017,7 @@ func (c *Client) CreateFolder(name string, groupIDs []int) (Folder, error) {
 	body, _ := json.Marshal(createFolder)
 	opts := NewBodyOptions().WithBody(string(body))
 
-	if err = c.Do(req, &folder, opts); err != nil {
+	if err = c.DoContext(ctx, req, &folder, opts); err != nil {
 		return folder, err
 	}
 
@@ -534,50 +1028,100 @@ func (c *Client) CreateFolder(name string, groupIDs []int) (Folder, error) {
 
 /**
  * FindOrCreateFolder attempts to find a folder with the given name, and if it doesn't exist, creates a new folder with that name.
+ * A folder is only created when the lookup fails with ErrNotFound, any other error is returned as is.
  *
  * @param name - The name of the folder to find or create.
  * @returns The found or created folder, and any error that occurred.
  */
 func (c *Client) FindOrCreateFolder(name string) (Folder, error) {
-	if folder, err := c.FindFolderByName(name); err == nil {
+	return c.FindOrCreateFolderContext(context.Background(), name)
+}
+
+// FindOrCreateFolderContext is like FindOrCreateFolder but carries ctx.
+func (c *Client) FindOrCreateFolderContext(ctx context.Context, name string) (Folder, error) {
+	folder, err := c.FindFolderByNameContext(ctx, name)
+	if err == nil {
 		log.Debug().Msgf("found folder [%s] with id [%d]", name, folder.ID)
 		return folder, nil
 	}
 
+	if !errors.Is(err, ErrNotFound) {
+		return Folder{}, err
+	}
+
 	log.Debug().Msgf("folder [%s] not found, creating", name)
 
-	group, err := c.FindGroupByName("All Admins")
+	group, err := c.FindGroupByNameContext(ctx, "All Admins")
 	if err != nil {
 		log.Debug().Msg("failed to find defalt admin group [All Admins]")
 		return Folder{}, err
 	}
 
-	return c.CreateFolder(name, []int{group.ID})
+	return c.CreateFolderContext(ctx, name, []int{group.ID})
 }
 
+/**
+ * FindMatterByName finds a matter by its exact name.
+ *
+ * Matter names are only unique within a folder, so an error wrapping ErrAmbiguous
+ * is returned when matters in several folders share the name. Use FindMatterInFolder
+ * to pick one of them.
+ *
+ * @param name - The name of the matter to find.
+ * @returns The found matter, and any error that occurred.
+ */
 func (c *Client) FindMatterByName(name string) (Matter, error) {
-	var err error
-	var matters Matters = Matters{}
+	return c.FindMatterByNameContext(context.Background(), name)
+}
 
-	log.Debug().Msgf("searching matter by name [%s]", name)
+// FindMatterByNameContext is like FindMatterByName but carries ctx.
+func (c *Client) FindMatterByNameContext(ctx context.Context, name string) (Matter, error) {
+	matters, err := c.findMatters(ctx, name)
+	if err != nil {
+		return Matter{}, err
+	}
 
-	/*
-		filterName helps to limit the results to folders CONTAINING the provided name.
-	*/
-	req, _ := NewRequest().WithTenant(c.tenant).Get().Matter().Build()
-	opts := NewListOptions().WithFilterName(name)
+	return ExactlyOne(matters, "matter", name, func(matter Matter) int { return matter.ID })
+}
+
+/**
+ * FindMatterInFolder finds a matter by its exact name within the folder with folderID.
+ * The folder of a matter is taken from its folder link, an error wrapping ErrNoFolderLink
+ * is returned when a matter with the name has none.
+ *
+ * @param name - The name of the matter to find.
+ * @param folderID - The ID of the folder holding the matter.
+ * @returns The found matter, and any error that occurred.
+ */
+func (c *Client) FindMatterInFolder(name string, folderID int) (Matter, error) {
+	return c.FindMatterInFolderContext(context.Background(), name, folderID)
+}
 
-	if matters, err = c.GetMatters(req, opts); err != nil {
+// FindMatterInFolderContext is like FindMatterInFolder but carries ctx.
+func (c *Client) FindMatterInFolderContext(ctx context.Context, name string, folderID int) (Matter, error) {
+	matters, err := c.findMatters(ctx, name)
+	if err != nil {
 		return Matter{}, err
 	}
 
-	for _, matter := range matters {
-		if matter.Name == name {
-			return matter, nil
-		}
+	// a matter without a folder link may be the one in folderID
+	if i := slices.IndexFunc(matters, func(matter Matter) bool { return matter.FolderID() == 0 }); i >= 0 {
+		return Matter{}, fmt.Errorf("matter [%s] with id %d %w", name, matters[i].ID, ErrNoFolderLink)
 	}
 
-	return Matter{}, fmt.Errorf("matter [%s] not found", name)
+	matters = slices.DeleteFunc(matters, func(matter Matter) bool { return matter.FolderID() != folderID })
+	return ExactlyOne(matters, "matter", name, func(matter Matter) int { return matter.ID })
+}
+
+// findMatters returns the matters with the exact name, in every folder.
+func (c *Client) findMatters(ctx context.Context, name string) (Matters, error) {
+	log.Debug().Msgf("searching matter by name [%s]", name)
+
+	req, _ := NewRequest().WithTenant(c.tenant).Get().Matter().Build()
+
+	return findEntities(ctx, c, req, name, unmarshalMatters, func(matter Matter) bool {
+		return matter.Name == name
+	})
 }
 
 /**
@@ -588,6 +1132,11 @@ func (c *Client) FindMatterByName(name string) (Matter, error) {
  * @returns The created matter, and any error that occurred.
  */
 func (c *Client) CreateMatter(name string, folderID int) (Matter, error) {
+	return c.CreateMatterContext(context.Background(), name, folderID)
+}
+
+// CreateMatterContext is like CreateMatter but carries ctx.
+func (c *Client) CreateMatterContext(ctx context.Context, name string, folderID int) (Matter, error) {
 	var err error
 	var matter Matter = Matter{}
 
@@ -597,7 +1146,7 @@ func (c *Client) CreateMatter(name string, folderID int) (Matter, error) {
 	body, _ := json.Marshal(createMatter)
 	opts := NewBodyOptions().WithBody(string(body))
 
-	if err = c.Do(req, &matter, opts); err != nil {
+	if err = c.DoContext(ctx, req, &matter, opts); err != nil {
 		return matter, err
 	}
 
@@ -607,21 +1156,33 @@ func (c *Client) CreateMatter(name string, folderID int) (Matter, error) {
 }
 
 /**
- * FindOrCreateMatter finds a matter by name, or creates a new matter if it doesn't exist.
+ * FindOrCreateMatter finds a matter by name in the folder with folderID, or creates a new matter there if it doesn't exist.
+ * A matter is only created when the lookup fails with ErrNotFound, any other error, e.g. one wrapping
+ * ErrNoFolderLink, is returned as is.
  *
  * @param name - The name of the matter to find or create.
- * @param folderID - The ID of the folder to associate the new matter with if it needs to be created.
+ * @param folderID - The ID of the folder holding the matter, or to create it in.
  * @returns The found or created matter, and any error that occurred.
  */
 func (c *Client) FindOrCreateMatter(name string, folderID int) (Matter, error) {
-	if matter, err := c.FindMatterByName(name); err == nil {
+	return c.FindOrCreateMatterContext(context.Background(), name, folderID)
+}
+
+// FindOrCreateMatterContext is like FindOrCreateMatter but carries ctx.
+func (c *Client) FindOrCreateMatterContext(ctx context.Context, name string, folderID int) (Matter, error) {
+	matter, err := c.FindMatterInFolderContext(ctx, name, folderID)
+	if err == nil {
 		log.Debug().Msgf("found matter [%s] with id [%d]", name, matter.ID)
 		return matter, nil
 	}
 
+	if !errors.Is(err, ErrNotFound) {
+		return Matter{}, err
+	}
+
 	log.Debug().Msgf("matter [%s] not found, creating", name)
 
-	return c.CreateMatter(name, folderID)
+	return c.CreateMatterContext(ctx, name, folderID)
 }
 
 /**
@@ -632,47 +1193,50 @@ func (c *Client) FindOrCreateMatter(name string, folderID int) (Matter, error) {
  * @returns The found legalhold, and any error that occurred.
  */
 func (c *Client) FindLegalhold(name string, matterID int) (Legalhold, error) {
-	var err error
-	var legalholds Legalholds = Legalholds{}
+	return c.FindLegalholdContext(context.Background(), name, matterID)
+}
 
+// FindLegalholdContext is like FindLegalhold but carries ctx.
+func (c *Client) FindLegalholdContext(ctx context.Context, name string, matterID int) (Legalhold, error) {
 	log.Debug().Msgf("searching legalhold by name [%s] and matterID [%d]", name, matterID)
 
 	req, _ := NewRequest().WithTenant(c.tenant).Get().Legalhold().Build()
-	opts := NewListOptions().WithFilterName(name)
 
-	if legalholds, err = c.GetLegalholds(req, opts); err != nil {
+	legalholds, err := findEntities(ctx, c, req, name, unmarshalLegalholds, func(legalhold Legalhold) bool {
+		return legalhold.Name == name && legalhold.MatterID == matterID
+	})
+	if err != nil {
 		return Legalhold{}, err
 	}
 
-	for _, legalhold := range legalholds {
-		if legalhold.Name == name && legalhold.MatterID == matterID {
-			return legalhold, nil
-		}
-	}
-
-	return Legalhold{}, fmt.Errorf("legalhold [%s] not found", name)
+	return ExactlyOne(legalholds, "legalhold", name, func(legalhold Legalhold) int { return legalhold.ID })
 }
 
+/**
+ * FindSilenthold finds a silenthold by name and matter ID.
+ *
+ * @param name - The name of the silenthold to find.
+ * @param matterID - The ID of the matter associated with the silenthold.
+ * @returns The found silenthold, and any error that occurred.
+ */
 func (c *Client) FindSilenthold(name string, matterID int) (Silenthold, error) {
-	var err error
-	var silentholds Silentholds = Silentholds{}
+	return c.FindSilentholdContext(context.Background(), name, matterID)
+}
 
-	log.Debug().Msgf("searching legalhold by name [%s] and matterID [%d]", name, matterID)
+// FindSilentholdContext is like FindSilenthold but carries ctx.
+func (c *Client) FindSilentholdContext(ctx context.Context, name string, matterID int) (Silenthold, error) {
+	log.Debug().Msgf("searching silenthold by name [%s] and matterID [%d]", name, matterID)
 
 	req, _ := NewRequest().WithTenant(c.tenant).Get().Silenthold().Build()
-	opts := NewListOptions().WithFilterName(name)
 
-	if silentholds, err = c.GetSilentholds(req, opts); err != nil {
+	silentholds, err := findEntities(ctx, c, req, name, unmarshalSilentholds, func(silenthold Silenthold) bool {
+		return silenthold.Name == name && silenthold.MatterID == matterID
+	})
+	if err != nil {
 		return Silenthold{}, err
 	}
 
-	for _, silenthold := range silentholds {
-		if silenthold.Name == name && silenthold.MatterID == matterID {
-			return silenthold, nil
-		}
-	}
-
-	return Silenthold{}, fmt.Errorf("silent [%s] not found", name)
+	return ExactlyOne(silentholds, "silenthold", name, func(silenthold Silenthold) int { return silenthold.ID })
 }
 
 // FindGroupByName searches for a group by name.
@@ -684,23 +1248,129 @@ func (c *Client) FindSilenthold(name string, matterID int) (Silenthold, error) {
 // - Group: the group found, or an empty Group if not found.
 // - error: any error that occurred during the search.
 func (c *Client) FindGroupByName(name string) (Group, error) {
-	var err error
-	var groups Groups = Groups{}
+	return c.FindGroupByNameContext(context.Background(), name)
+}
 
+// FindGroupByNameContext is like FindGroupByName but carries ctx.
+func (c *Client) FindGroupByNameContext(ctx context.Context, name string) (Group, error) {
 	log.Debug().Msgf("searching groups by name [%s]", name)
 
 	req, _ := NewRequest().WithTenant(c.tenant).Get().Group().Build()
-	opts := NewListOptions().WithFilterName(name)
 
-	if groups, err = c.GetGroups(req, opts); err != nil {
+	groups, err := findEntities(ctx, c, req, name, unmarshalGroups, func(group Group) bool {
+		return group.Name == name
+	})
+	if err != nil {
 		return Group{}, err
 	}
 
-	for _, group := range groups {
-		if group.Name == name {
-			return group, nil
-		}
+	return ExactlyOne(groups, "group", name, func(group Group) int { return group.ID })
+}
+
+// FindCustodianByNameAndEmail searches for a custodian matching both name and email.
+//
+// Parameters:
+// - name: the name of the custodian to search for.
+// - email: the email of the custodian to search for.
+//
+// Returns:
+// - Custodian: the custodian found, or an empty Custodian if not found.
+// - error: an error wrapping ErrNotFound or ErrAmbiguous, or any error that occurred during the search.
+func (c *Client) FindCustodianByNameAndEmail(name, email string) (Custodian, error) {
+	return c.FindCustodianByNameAndEmailContext(context.Background(), name, email)
+}
+
+// FindCustodianByNameAndEmailContext is like FindCustodianByNameAndEmail but carries ctx.
+func (c *Client) FindCustodianByNameAndEmailContext(ctx context.Context, name, email string) (Custodian, error) {
+	c.redactor.AddName(name)
+	log.Debug().Msgf("searching custodian by name [%s] and email [%s]", name, email)
+
+	req, _ := NewRequest().WithTenant(c.tenant).Get().Custodian().Build()
+
+	custodians, err := findEntities(ctx, c, req, name, unmarshalCustodians, func(custodian Custodian) bool {
+		return custodian.Name == name && custodian.Email == email
+	})
+	if err != nil {
+		return Custodian{}, err
 	}
 
-	return Group{}, fmt.Errorf("group [%s] not found", name)
+	return ExactlyOne(custodians, "custodian", fmt.Sprintf("%s <%s>", name, email), func(custodian Custodian) int { return custodian.ID })
+}
+
+/**
+ * DeleteFolder deletes the folder with the given ID.
+ *
+ * Check Folder.CanBeDeleted first, the service tells there whether the folder may be removed.
+ *
+ * @param id - The ID of the folder to delete.
+ * @returns Any error that occurred.
+ */
+func (c *Client) DeleteFolder(id int) error {
+	return c.DeleteFolderContext(context.Background(), id)
+}
+
+// DeleteFolderContext is like DeleteFolder but carries ctx.
+func (c *Client) DeleteFolderContext(ctx context.Context, id int) error {
+	req, _ := NewRequest().WithTenant(c.tenant).Delete().Folder().WithID(id).Build()
+	return c.delete(ctx, req)
+}
+
+/**
+ * DeleteMatter deletes the matter with the given ID.
+ *
+ * Check Matter.CanBeDeleted first, the service tells there whether the matter may be removed.
+ *
+ * @param id - The ID of the matter to delete.
+ * @returns Any error that occurred.
+ */
+func (c *Client) DeleteMatter(id int) error {
+	return c.DeleteMatterContext(context.Background(), id)
+}
+
+// DeleteMatterContext is like DeleteMatter but carries ctx.
+func (c *Client) DeleteMatterContext(ctx context.Context, id int) error {
+	req, _ := NewRequest().WithTenant(c.tenant).Delete().Matter().WithID(id).Build()
+	return c.delete(ctx, req)
+}
+
+// DeleteCustodian deletes the custodian with the given ID.
+func (c *Client) DeleteCustodian(id int) error {
+	return c.DeleteCustodianContext(context.Background(), id)
+}
+
+// DeleteCustodianContext is like DeleteCustodian but carries ctx.
+func (c *Client) DeleteCustodianContext(ctx context.Context, id int) error {
+	req, _ := NewRequest().WithTenant(c.tenant).Delete().Custodian().WithID(id).Build()
+	return c.delete(ctx, req)
+}
+
+// DeleteCustodianGroup deletes the custodian group with the given ID, its custodians are kept.
+func (c *Client) DeleteCustodianGroup(id int) error {
+	return c.DeleteCustodianGroupContext(context.Background(), id)
+}
+
+// DeleteCustodianGroupContext is like DeleteCustodianGroup but carries ctx.
+func (c *Client) DeleteCustodianGroupContext(ctx context.Context, id int) error {
+	req, _ := NewRequest().WithTenant(c.tenant).Delete().CustodianGroup().WithID(id).Build()
+	return c.delete(ctx, req)
+}
+
+// DeleteQuestionnaire deletes the questionnaire with the given ID.
+func (c *Client) DeleteQuestionnaire(id int) error {
+	return c.DeleteQuestionnaireContext(context.Background(), id)
+}
+
+// DeleteQuestionnaireContext is like DeleteQuestionnaire but carries ctx.
+func (c *Client) DeleteQuestionnaireContext(ctx context.Context, id int) error {
+	req, _ := NewRequest().WithTenant(c.tenant).Delete().Questionnaire().WithID(id).Build()
+	return c.delete(ctx, req)
+}
+
+func (c *Client) delete(ctx context.Context, req Requestor) error {
+	if _, err := c.SendContext(ctx, req); err != nil {
+		return err
+	}
+
+	log.Debug().Msgf("deleted %s", req.Endpoint())
+	return nil
 }
@@ -0,0 +1,150 @@
+package otlh_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/rs/zerolog"
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestMain(m *testing.M) {
+	// keep the debug and trace output of the client out of the test output
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	os.Exit(m.Run())
+}
+
+func TestFindMatter(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	folderA := srv.AddFolder(otlh.Folder{Name: "A"})
+	folderB := srv.AddFolder(otlh.Folder{Name: "B"})
+	folderC := srv.AddFolder(otlh.Folder{Name: "C"})
+
+	// "Acme" in folder A sits on the first page of the name filtered list,
+	// its namesakes in B and C several pages further down.
+	inA := srv.AddMatter(otlh.Matter{Name: "Acme"}, folderA.ID)
+	for i := range 2 * otlh.FIND_PAGE_SIZE {
+		srv.AddMatter(otlh.Matter{Name: fmt.Sprintf("Acme %03d", i)}, folderA.ID)
+	}
+	inB := srv.AddMatter(otlh.Matter{Name: "Acme"}, folderB.ID)
+	srv.AddMatter(otlh.Matter{Name: "Acme"}, folderC.ID)
+	srv.AddMatter(otlh.Matter{Name: "Acme"}, folderC.ID)
+
+	client := srv.Client()
+
+	tests := []struct {
+		name     string
+		folderID int
+		want     int
+		wantErr  error
+	}{
+		{name: "Acme", folderID: folderA.ID, want: inA.ID},
+		{name: "Acme", folderID: folderB.ID, want: inB.ID},
+		{name: "Acme", folderID: folderC.ID, wantErr: otlh.ErrAmbiguous},
+		{name: "Acme", wantErr: otlh.ErrAmbiguous},
+		{name: "Acme 150", want: -1},
+		{name: "Missing", wantErr: otlh.ErrNotFound},
+		{name: "Acme 150", folderID: folderB.ID, wantErr: otlh.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s in %d", tt.name, tt.folderID), func(t *testing.T) {
+			var matter otlh.Matter
+			var err error
+			if tt.folderID > 0 {
+				matter, err = client.FindMatterInFolder(tt.name, tt.folderID)
+			} else {
+				matter, err = client.FindMatterByName(tt.name)
+			}
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if matter.Name != tt.name || (tt.want > 0 && matter.ID != tt.want) {
+				t.Fatalf("got matter %d [%s], want %d [%s]", matter.ID, matter.Name, tt.want, tt.name)
+			}
+		})
+	}
+}
+
+func TestFindOrCreateMatterDoesNotDuplicate(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	folderA := srv.AddFolder(otlh.Folder{Name: "A"})
+	folderB := srv.AddFolder(otlh.Folder{Name: "B"})
+
+	srv.AddMatter(otlh.Matter{Name: "Acme"}, folderA.ID)
+	for i := range otlh.FIND_PAGE_SIZE {
+		srv.AddMatter(otlh.Matter{Name: fmt.Sprintf("Acme %03d", i)}, folderA.ID)
+	}
+	existing := srv.AddMatter(otlh.Matter{Name: "Acme"}, folderB.ID)
+
+	matter, err := srv.Client().FindOrCreateMatter("Acme", folderB.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if matter.ID != existing.ID {
+		t.Fatalf("got matter %d, want the existing matter %d", matter.ID, existing.ID)
+	}
+	if n := len(srv.Matters()); n != otlh.FIND_PAGE_SIZE+2 {
+		t.Fatalf("got %d matters, want %d", n, otlh.FIND_PAGE_SIZE+2)
+	}
+}
+
+func TestFindOrCreateMatterWithoutFolderLink(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	folder := srv.AddFolder(otlh.Folder{Name: "A"})
+	unfiled := srv.AddMatter(otlh.Matter{Name: "Acme"}, 0)
+
+	client := srv.Client()
+
+	_, err := client.FindMatterInFolder("Acme", folder.ID)
+	if !errors.Is(err, otlh.ErrNoFolderLink) {
+		t.Fatalf("got error %v, want %v", err, otlh.ErrNoFolderLink)
+	}
+
+	_, err = client.FindOrCreateMatter("Acme", folder.ID)
+	if !errors.Is(err, otlh.ErrNoFolderLink) {
+		t.Fatalf("got error %v, want %v", err, otlh.ErrNoFolderLink)
+	}
+	if matters := srv.Matters(); len(matters) != 1 || matters[0].ID != unfiled.ID {
+		t.Fatalf("got %d matters, want only the one without a folder link", len(matters))
+	}
+}
+
+func TestFindDoesNotTreatAPINotFoundAsMissing(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	srv.AddGroup(otlh.Group{Name: "All Admins"})
+
+	// the server answers 404 for paths outside its tenant
+	client := srv.ClientBuilder().WithTenant("wrong").Build()
+
+	_, err := client.FindOrCreateFolder("Acme")
+
+	var apiErr *otlh.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Fatalf("got error %v, want an APIError with status 404", err)
+	}
+	if errors.Is(err, otlh.ErrNotFound) {
+		t.Fatalf("a 404 response matched ErrNotFound: %v", err)
+	}
+	if n := len(srv.Folders()); n != 0 {
+		t.Fatalf("got %d folders, want none created", n)
+	}
+}
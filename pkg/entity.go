@@ -1,8 +1,11 @@
 package otlh
 
 import (
+	"context"
 	"encoding/json"
-	"time"
+	"fmt"
+	"iter"
+	"sync"
 
 	"github.com/rs/zerolog/log"
 	"github.com/schollz/progressbar/v3"
@@ -20,41 +23,284 @@ type DefaultEntityListInfo struct {
 	} `json:"page"`
 }
 
-func getAllEntities[T any](c *Client, req Requestor, opts Options, unmarshal func([]byte) ([]T, bool, int, error)) ([]T, error) {
-	var entities []T
-	var page int = 1
+// FIND_PAGE_SIZE is the page size used by the exact-name lookups.
+const FIND_PAGE_SIZE = 100
 
+// DEFAULT_PAGE_CONCURRENCY is the number of pages getAllEntities and iterEntities fetch at once.
+const DEFAULT_PAGE_CONCURRENCY = 4
+
+type unmarshalFunc[T any] func([]byte) ([]T, bool, int, error)
+
+/*
+getAllEntities fetches every page of req. The first page tells the total
+count and the page size, the remaining pages are then fetched by up to
+c.pageConcurrency workers and put back in page order. Every request goes
+through SendContext, so the rate limiter and the retry policy apply to each
+page on its own.
+*/
+func getAllEntities[T any](ctx context.Context, c *Client, req Requestor, opts Options, unmarshal unmarshalFunc[T]) ([]T, error) {
 	log.Debug().Msg("Getting all entities")
 
 	bar := progressbar.Default(100)
 	defer bar.Finish()
 
-	for {
-		opts.(*ListOptions).WithPageNumber(page)
+	listOpts := opts.(*ListOptions)
+
+	first, hasMore, totalCount, err := fetchPage(ctx, c, req, *listOpts, 1, unmarshal)
+	if err != nil {
+		return nil, err
+	}
+
+	bar.ChangeMax(totalCount)
+	bar.Add(len(first))
+
+	entities := first
+	if !hasMore {
+		return entities, nil
+	}
+
+	pageSize := len(first)
+	lastPage := 1
+	if pageSize > 0 {
+		lastPage = (totalCount + pageSize - 1) / pageSize
+	}
 
-		resp, err := c.Send(req, opts)
+	if lastPage > 1 {
+		hasMore, err = streamPages(ctx, c, req, *listOpts, 2, lastPage, unmarshal, func(page []T) bool {
+			bar.Add(len(page))
+			entities = append(entities, page...)
+			return true
+		})
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		pageEntities, hasMore, totalCount, err := unmarshal(resp)
+	// entities added while paging, or a total count the server got wrong,
+	// leave pages after lastPage: pick them up one at a time
+	if hasMore {
+		err = walkEntitiesFrom(ctx, c, req, listOpts, lastPage+1, unmarshal, func(page []T, _ int) bool {
+			bar.Add(len(page))
+			entities = append(entities, page...)
+			return true
+		})
 		if err != nil {
 			return nil, err
 		}
+	}
+
+	return entities, nil
+}
+
+// fetchPage fetches page number page of req, opts is copied so concurrent callers don't share it.
+func fetchPage[T any](ctx context.Context, c *Client, req Requestor, opts ListOptions, page int, unmarshal unmarshalFunc[T]) ([]T, bool, int, error) {
+	opts.WithPageNumber(page)
+
+	resp, err := c.SendContext(ctx, req, &opts)
+	if err != nil {
+		return nil, false, 0, err
+	}
+
+	return unmarshal(resp)
+}
+
+// pageResult is the outcome of one fetchPage call made by streamPages.
+type pageResult[T any] struct {
+	entities []T
+	hasMore  bool
+	err      error
+}
+
+/*
+streamPages fetches pages from to last of req with up to c.pageConcurrency
+requests in flight and hands them to fn in page order. A page is passed on as
+soon as it and every page before it have arrived, while the following pages
+are still being fetched. It returns the has-more flag of the last page; when
+fn returns false or a request fails, the pages still pending are cancelled.
+*/
+func streamPages[T any](ctx context.Context, c *Client, req Requestor, opts ListOptions, from int, last int, unmarshal unmarshalFunc[T], fn func(page []T) bool) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	// pending holds one channel per page in flight, oldest page first
+	var pending []chan pageResult[T]
+	next := from
+
+	fetch := func() {
+		page := next
+		next++
+
+		result := make(chan pageResult[T], 1)
+		pending = append(pending, result)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entities, hasMore, _, err := fetchPage(ctx, c, req, opts, page, unmarshal)
+			result <- pageResult[T]{entities: entities, hasMore: hasMore, err: err}
+		}()
+	}
+
+	for next <= last && len(pending) < max(1, c.pageConcurrency) {
+		fetch()
+	}
+
+	for page := from; page <= last; page++ {
+		result := <-pending[0]
+		pending = pending[1:]
+		if result.err != nil {
+			return false, result.err
+		}
 
-		bar.ChangeMax(totalCount)
-		bar.Add(opts.(*ListOptions).pageSize)
-		time.Sleep(5 * time.Millisecond)
+		// keep the window full while fn works on this page
+		if next <= last {
+			fetch()
+		}
 
-		entities = append(entities, pageEntities...)
-		if !hasMore {
-			break
+		if !fn(result.entities) {
+			return false, nil
+		}
+		if page == last {
+			return result.hasMore, nil
+		}
+	}
+
+	return false, nil
+}
+
+/*
+iterEntities returns an iterator over every entity of req. Like
+getAllEntities it reads the first page, then fetches the remaining pages with
+up to c.pageConcurrency requests in flight, yielding entities in page order as
+soon as their page is in. Breaking out of the loop cancels the pages still
+pending and stops paging. A failed request ends the iteration with its error.
+*/
+func iterEntities[T any](ctx context.Context, c *Client, req Requestor, opts Options, unmarshal unmarshalFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		var stopped bool
+
+		each := func(page []T) bool {
+			for _, entity := range page {
+				if !yield(entity, nil) {
+					stopped = true
+					return false
+				}
+			}
+			return true
+		}
+
+		listOpts := opts.(*ListOptions)
+
+		first, hasMore, totalCount, err := fetchPage(ctx, c, req, *listOpts, 1, unmarshal)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		if !each(first) || !hasMore {
+			return
+		}
+
+		lastPage := 1
+		if pageSize := len(first); pageSize > 0 {
+			lastPage = (totalCount + pageSize - 1) / pageSize
+		}
+
+		if lastPage > 1 {
+			hasMore, err = streamPages(ctx, c, req, *listOpts, 2, lastPage, unmarshal, each)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+		}
+
+		// as in getAllEntities, pages after lastPage are read one at a time
+		if hasMore && !stopped {
+			err = walkEntitiesFrom(ctx, c, req, listOpts, lastPage+1, unmarshal, func(page []T, _ int) bool {
+				return each(page)
+			})
+			if err != nil && !stopped {
+				yield(zero, err)
+			}
+		}
+	}
+}
+
+/*
+walkEntities requests req page by page and hands every page to fn together
+with the total count reported by the server. It stops after the last page or
+as soon as fn returns false.
+*/
+func walkEntities[T any](ctx context.Context, c *Client, req Requestor, opts Options, unmarshal unmarshalFunc[T], fn func(page []T, totalCount int) bool) error {
+	return walkEntitiesFrom(ctx, c, req, opts.(*ListOptions), 1, unmarshal, fn)
+}
+
+// walkEntitiesFrom is walkEntities starting at page number page.
+func walkEntitiesFrom[T any](ctx context.Context, c *Client, req Requestor, opts *ListOptions, page int, unmarshal unmarshalFunc[T], fn func(page []T, totalCount int) bool) error {
+	for {
+		pageEntities, hasMore, totalCount, err := fetchPage(ctx, c, req, *opts, page, unmarshal)
+		if err != nil {
+			return err
+		}
+
+		if !fn(pageEntities, totalCount) || !hasMore {
+			return nil
 		}
 
 		page++
 	}
+}
 
-	return entities, nil
+/*
+findEntities pages through all results of req filtered by name, which the
+server matches as a substring, and returns every entity accepted by match.
+Reading every page lets ExactlyOne tell not found from ambiguous however the
+matches are spread across pages.
+*/
+func findEntities[T any](ctx context.Context, c *Client, req Requestor, name string, unmarshal unmarshalFunc[T], match func(T) bool) ([]T, error) {
+	var found []T
+
+	opts := NewListOptions().WithFilterName(name).WithPageSize(FIND_PAGE_SIZE)
+
+	err := walkEntities(ctx, c, req, opts, unmarshal, func(page []T, _ int) bool {
+		for _, entity := range page {
+			if match(entity) {
+				found = append(found, entity)
+			}
+		}
+		return true
+	})
+
+	return found, err
+}
+
+/*
+ExactlyOne returns the only entity in found, the exact matches of name. It
+returns an error wrapping ErrNotFound when found is empty and one wrapping
+ErrAmbiguous, listing the ids of the candidates, when there are several.
+Lookups outside the client use it to follow the rules of the Find* helpers.
+*/
+func ExactlyOne[T any](found []T, kind string, name string, id func(T) int) (T, error) {
+	var zero T
+
+	switch len(found) {
+	case 0:
+		return zero, fmt.Errorf("%s [%s] %w", kind, name, ErrNotFound)
+	case 1:
+		return found[0], nil
+	}
+
+	ids := make([]int, len(found))
+	for i, entity := range found {
+		ids[i] = id(entity)
+	}
+
+	return zero, fmt.Errorf("%s [%s] %w: %d exact matches with ids %v", kind, name, ErrAmbiguous, len(found), ids)
 }
 
 func unmarshalCustodians(data []byte) ([]Custodian, bool, int, error) {
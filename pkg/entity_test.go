@@ -0,0 +1,149 @@
+package otlh_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+const testPageSize = 10
+
+// newPagedServer returns a server with n custodians named "Custodian 000" and up.
+func newPagedServer(t *testing.T, n int) *otlhtest.Server {
+	t.Helper()
+
+	srv := otlhtest.NewServer("demo")
+	t.Cleanup(srv.Close)
+
+	for i := range n {
+		srv.AddCustodian(otlh.Custodian{Name: fmt.Sprintf("Custodian %03d", i), Email: fmt.Sprintf("c%03d@acme.com", i)})
+	}
+	return srv
+}
+
+func custodiansRequest(client *otlh.Client) (otlh.Requestor, *otlh.ListOptions) {
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Custodian().Build()
+	return req, otlh.NewListOptions().WithPageSize(testPageSize)
+}
+
+func TestPagingKeepsOrder(t *testing.T) {
+	srv := newPagedServer(t, 9*testPageSize+3)
+
+	for _, concurrency := range []int{1, 2, 4, 16} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			client := srv.ClientBuilder().WithPageConcurrency(concurrency).Build()
+
+			req, opts := custodiansRequest(client)
+			all, err := client.GetAllCustodians(req, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var iterated []otlh.Custodian
+			for custodian, err := range client.IterCustodians(custodiansRequest(client)) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				iterated = append(iterated, custodian)
+			}
+
+			want := srv.Custodians()
+			for name, got := range map[string][]otlh.Custodian{"GetAll": all, "Iter": iterated} {
+				if len(got) != len(want) {
+					t.Fatalf("%s: got %d custodians, want %d", name, len(got), len(want))
+				}
+				for i := range want {
+					if got[i].ID != want[i].ID {
+						t.Fatalf("%s: got custodian %d at %d, want %d", name, got[i].ID, i, want[i].ID)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestIterFetchesPagesConcurrently(t *testing.T) {
+	const delay = 100 * time.Millisecond
+	const pages = 9
+
+	srv := newPagedServer(t, pages*testPageSize)
+	srv.InjectFault(otlhtest.Fault{Method: http.MethodGet, Path: "/custodians", Delay: delay})
+
+	client := srv.ClientBuilder().WithPageConcurrency(4).Build()
+
+	start := time.Now()
+	var count int
+	for _, err := range client.IterCustodians(custodiansRequest(client)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		count++
+	}
+	elapsed := time.Since(start)
+
+	if count != pages*testPageSize {
+		t.Fatalf("got %d custodians, want %d", count, pages*testPageSize)
+	}
+	// one page at a time takes pages*delay, four at once about a third of it
+	if elapsed >= pages*delay*2/3 {
+		t.Fatalf("paging took %v, want the pages fetched concurrently", elapsed)
+	}
+}
+
+func TestIterStopsPaging(t *testing.T) {
+	srv := newPagedServer(t, 20*testPageSize)
+
+	const concurrency = 3
+	client := srv.ClientBuilder().WithPageConcurrency(concurrency).Build()
+
+	var count int
+	for _, err := range client.IterCustodians(custodiansRequest(client)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count++; count == testPageSize+1 {
+			break
+		}
+	}
+
+	// the first page, the window ahead of it and the page refilling it
+	if n := len(srv.Requests()); n > 2+concurrency {
+		t.Fatalf("got %d requests after breaking on page 2, want at most %d", n, 2+concurrency)
+	}
+}
+
+func TestIterEndsWithPageError(t *testing.T) {
+	srv := newPagedServer(t, 5*testPageSize)
+
+	client := srv.ClientBuilder().
+		WithRetryPolicy(otlh.RetryPolicy{MaxAttempts: 1}).
+		WithPageConcurrency(2).
+		Build()
+
+	var count int
+	var iterErr error
+	for custodian, err := range client.IterCustodians(custodiansRequest(client)) {
+		if err != nil {
+			iterErr = err
+			continue
+		}
+		if custodian.ID != srv.Custodians()[count].ID {
+			t.Fatalf("got custodian %d at %d, want them in order", custodian.ID, count)
+		}
+		if count++; count == 1 {
+			// the remaining pages are requested once the first one is consumed
+			srv.InjectFault(otlhtest.Fault{Method: http.MethodGet, Path: "/custodians", StatusCode: http.StatusInternalServerError, Times: 1})
+		}
+	}
+
+	if iterErr == nil {
+		t.Fatal("got no error from a failed page")
+	}
+	if count < testPageSize || count%testPageSize != 0 || count == 5*testPageSize {
+		t.Fatalf("got %d custodians before the error, want whole pages and not all of them", count)
+	}
+}
@@ -0,0 +1,131 @@
+package otlh
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+/*
+ErrNotFound is returned, wrapped, by the Find* helpers when the lookup succeeds
+but no entity matches. A 404 response, e.g. from a wrong tenant or a proxy, is
+an *APIError and does not match it, so it is never mistaken for a missing
+entity. Use errors.Is to tell it apart from transport and API failures.
+*/
+var ErrNotFound = errors.New("not found")
+
+/*
+ErrAmbiguous is returned, wrapped, by the Find* helpers when more than one
+entity has the exact name looked up, e.g. the same matter name in two folders.
+*/
+var ErrAmbiguous = errors.New("is ambiguous")
+
+/*
+ErrNoFolderLink is returned, wrapped, by FindMatterInFolder when a matter with
+the name has no folder link, so it can not be told whether it is in the folder.
+FindOrCreateMatter returns it instead of creating a possible duplicate.
+*/
+var ErrNoFolderLink = errors.New("has no folder link")
+
+/*
+ErrRemindersNotAllowed is returned, wrapped with the reason the service gives,
+by SendLegalholdReminders when the hold's can_send_* field does not allow the
+reminders.
+*/
+var ErrRemindersNotAllowed = errors.New("reminders can not be sent")
+
+/*
+APIError is returned by Client.Send when the server responds with a non-2xx
+status code. It keeps the raw response body together with the "error" and
+"errors" fields the API uses to report validation failures, so callers can
+inspect them with errors.As.
+*/
+type APIError struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	RequestID  string
+	Body       []byte
+
+	// Message and Errors hold the "error" and "errors" fields of the
+	// response body when it is JSON, see CustodiansSyncResponse.
+	Message any
+	Errors  any
+}
+
+func newAPIError(req Requestor, resp *resty.Response) *APIError {
+	e := &APIError{
+		Method:     req.Method().String(),
+		Endpoint:   req.Endpoint(),
+		StatusCode: resp.StatusCode(),
+		RequestID:  resp.Header().Get("X-Request-Id"),
+		Body:       resp.Body(),
+	}
+
+	var body CustodiansSyncResponse
+	if json.Unmarshal(e.Body, &body) == nil {
+		e.Message = body.Error
+		e.Errors = body.Errors
+	}
+
+	return e
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: unexpected status code: %d", e.Method, e.Endpoint, e.StatusCode)
+	if messages := e.Messages(); len(messages) > 0 {
+		msg += ": " + strings.Join(messages, "; ")
+	}
+	return msg
+}
+
+/*
+Messages flattens the "error" and "errors" fields into readable strings.
+Field level errors such as {"name": ["can't be blank"]} are rendered as
+"name: can't be blank".
+*/
+func (e *APIError) Messages() []string {
+	var messages []string
+	messages = appendMessages(messages, "", e.Message)
+	messages = appendMessages(messages, "", e.Errors)
+	return messages
+}
+
+func appendMessages(messages []string, prefix string, v any) []string {
+	switch t := v.(type) {
+	case nil:
+	case string:
+		if t == "" {
+			break
+		}
+		if prefix != "" {
+			t = prefix + ": " + t
+		}
+		messages = append(messages, t)
+	case []any:
+		for _, item := range t {
+			messages = appendMessages(messages, prefix, item)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			p := k
+			if prefix != "" {
+				p = prefix + "." + k
+			}
+			messages = appendMessages(messages, p, t[k])
+		}
+	default:
+		messages = appendMessages(messages, prefix, fmt.Sprint(t))
+	}
+	return messages
+}
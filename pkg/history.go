@@ -0,0 +1,157 @@
+package otlh
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+)
+
+// events of the history of a hold about one custodian
+const (
+	HISTORY_ADDED                         = "custodian_added"
+	HISTORY_NOTICE_SENT                   = "hold_notice_sent"
+	HISTORY_ACKNOWLEDGEMENT_REMINDER_SENT = "acknowledgement_reminder_sent"
+	HISTORY_HOLD_REMINDER_SENT            = "hold_reminder_sent"
+	HISTORY_ACKNOWLEDGED                  = "acknowledged"
+	HISTORY_RELEASED                      = "released"
+	HISTORY_RELEASE_NOTICE_SENT           = "release_notice_sent"
+)
+
+/*
+HistoryEvent is one entry of the history of a legal or silent hold, e.g. the
+hold notice sent to a custodian, their acknowledgement or their release.
+Custodian is empty, and marshalled as an empty object, for events about the
+hold itself.
+*/
+type HistoryEvent struct {
+	ID          int    `json:"id"`
+	Event       string `json:"event"`
+	Description string `json:"description,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	Custodian   struct {
+		ID    int    `json:"id,omitempty"`
+		Name  string `json:"name,omitempty"`
+		Email string `json:"email,omitempty"`
+	} `json:"custodian"`
+	PerformedBy struct {
+		Name string `json:"name,omitempty"`
+		Type string `json:"type,omitempty"`
+	} `json:"performed_by"`
+}
+
+type HistoryEvents []HistoryEvent
+
+// Time returns CreatedAt parsed, the zero time if it is not RFC 3339.
+func (e HistoryEvent) Time() time.Time {
+	t, _ := time.Parse(time.RFC3339, e.CreatedAt)
+	return t
+}
+
+/*
+HistoryFilter selects history events. Email matches the custodian's email,
+ignoring case; Since and Until bound CreatedAt, both inclusive. Zero values
+select everything.
+*/
+type HistoryFilter struct {
+	Email string
+	Since time.Time
+	Until time.Time
+}
+
+// Filter returns the events selected by f, in their original order.
+func (events HistoryEvents) Filter(f HistoryFilter) HistoryEvents {
+	selected := HistoryEvents{}
+
+	for _, e := range events {
+		if f.Email != "" && !strings.EqualFold(e.Custodian.Email, f.Email) {
+			continue
+		}
+
+		if !f.Since.IsZero() || !f.Until.IsZero() {
+			// events without a valid time can not be placed in the range
+			t := e.Time()
+			if t.IsZero() || (!f.Since.IsZero() && t.Before(f.Since)) || (!f.Until.IsZero() && t.After(f.Until)) {
+				continue
+			}
+		}
+
+		selected = append(selected, e)
+	}
+
+	return selected
+}
+
+/*
+latest returns the latest event of each custodian among those of the given
+kinds. Events are ordered by CreatedAt, those at the same time by their
+position in events.
+*/
+func (events HistoryEvents) latest(kinds ...string) map[int]HistoryEvent {
+	latest := map[int]HistoryEvent{}
+	for _, e := range events {
+		if !slices.Contains(kinds, e.Event) {
+			continue
+		}
+		if last, ok := latest[e.Custodian.ID]; !ok || !e.Time().Before(last.Time()) {
+			latest[e.Custodian.ID] = e
+		}
+	}
+	return latest
+}
+
+/*
+Pending returns the ids of the custodians in ids that, according to events,
+still owe a response to their hold: no acknowledgement or release comes
+after their latest addition to the hold or hold notice. Custodians added
+again, or sent the notice again, after acknowledging or being released are
+pending.
+*/
+func (events HistoryEvents) Pending(ids []int) []int {
+	latest := events.latest(HISTORY_ADDED, HISTORY_NOTICE_SENT, HISTORY_ACKNOWLEDGED, HISTORY_RELEASED)
+
+	pending := []int{}
+	for _, id := range ids {
+		if e, ok := latest[id]; !ok || e.Event == HISTORY_ADDED || e.Event == HISTORY_NOTICE_SENT {
+			pending = append(pending, id)
+		}
+	}
+	return pending
+}
+
+/*
+Released returns the ids of the custodians in ids that, according to events,
+are released now: their latest membership event is a release. Custodians
+added to the hold again, or sent its notice, after a release are on the hold.
+*/
+func (events HistoryEvents) Released(ids []int) []int {
+	latest := events.latest(HISTORY_ADDED, HISTORY_NOTICE_SENT, HISTORY_RELEASED)
+
+	released := []int{}
+	for _, id := range ids {
+		if e, ok := latest[id]; ok && e.Event == HISTORY_RELEASED {
+			released = append(released, id)
+		}
+	}
+	return released
+}
+
+// GetLegalholdHistory returns the complete history of a legal hold, page by page.
+func (c *Client) GetLegalholdHistory(legalhold Legalhold) (HistoryEvents, error) {
+	return c.GetLegalholdHistoryContext(context.Background(), legalhold)
+}
+
+// GetLegalholdHistoryContext is like GetLegalholdHistory but carries ctx.
+func (c *Client) GetLegalholdHistoryContext(ctx context.Context, legalhold Legalhold) (HistoryEvents, error) {
+	return followList[HistoryEvent](ctx, c, legalhold.Links.History.Href, "history")
+}
+
+// GetSilentholdHistory returns the complete history of a silent hold, page by page.
+func (c *Client) GetSilentholdHistory(silenthold Silenthold) (HistoryEvents, error) {
+	return c.GetSilentholdHistoryContext(context.Background(), silenthold)
+}
+
+// GetSilentholdHistoryContext is like GetSilentholdHistory but carries ctx.
+func (c *Client) GetSilentholdHistoryContext(ctx context.Context, silenthold Silenthold) (HistoryEvents, error) {
+	return followList[HistoryEvent](ctx, c, silenthold.Links.History.Href, "history")
+}
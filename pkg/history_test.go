@@ -0,0 +1,205 @@
+package otlh_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func custodianEvent(id int, event string, createdAt string) otlh.HistoryEvent {
+	e := otlh.HistoryEvent{Event: event, CreatedAt: createdAt}
+	e.Custodian.ID = id
+	return e
+}
+
+func TestHistoryEventsReleased(t *testing.T) {
+	tests := []struct {
+		name   string
+		events otlh.HistoryEvents
+		want   []int
+	}{
+		{
+			name:   "never released",
+			events: otlh.HistoryEvents{custodianEvent(1, otlh.HISTORY_NOTICE_SENT, "2026-01-01T00:00:00Z")},
+			want:   []int{},
+		},
+		{
+			name: "released",
+			events: otlh.HistoryEvents{
+				custodianEvent(1, otlh.HISTORY_NOTICE_SENT, "2026-01-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_RELEASE_NOTICE_SENT, "2026-02-01T00:00:00Z"),
+			},
+			want: []int{1},
+		},
+		{
+			name: "added again after release",
+			events: otlh.HistoryEvents{
+				custodianEvent(1, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_ADDED, "2026-03-01T00:00:00Z"),
+			},
+			want: []int{},
+		},
+		{
+			name: "sent the notice again after release",
+			events: otlh.HistoryEvents{
+				custodianEvent(1, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_NOTICE_SENT, "2026-03-01T00:00:00Z"),
+			},
+			want: []int{},
+		},
+		{
+			name: "released again after being added again",
+			events: otlh.HistoryEvents{
+				custodianEvent(1, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_ADDED, "2026-03-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_RELEASED, "2026-04-01T00:00:00Z"),
+			},
+			want: []int{1},
+		},
+		{
+			name: "newest first",
+			events: otlh.HistoryEvents{
+				custodianEvent(1, otlh.HISTORY_ADDED, "2026-03-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+			},
+			want: []int{},
+		},
+		{
+			name: "same time, later position wins",
+			events: otlh.HistoryEvents{
+				custodianEvent(1, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_ADDED, "2026-02-01T00:00:00Z"),
+				custodianEvent(2, otlh.HISTORY_ADDED, "2026-02-01T00:00:00Z"),
+				custodianEvent(2, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+			},
+			want: []int{2},
+		},
+		{
+			name: "reminders and acknowledgements do not change membership",
+			events: otlh.HistoryEvents{
+				custodianEvent(1, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_HOLD_REMINDER_SENT, "2026-03-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_ACKNOWLEDGED, "2026-03-02T00:00:00Z"),
+			},
+			want: []int{1},
+		},
+		{
+			name:   "other custodians",
+			events: otlh.HistoryEvents{custodianEvent(3, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z")},
+			want:   []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.events.Released([]int{1, 2}); !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryEventsPending(t *testing.T) {
+	tests := []struct {
+		name   string
+		events otlh.HistoryEvents
+		want   []int
+	}{
+		{
+			name:   "no events",
+			events: otlh.HistoryEvents{},
+			want:   []int{1, 2},
+		},
+		{
+			name: "notice sent",
+			events: otlh.HistoryEvents{
+				custodianEvent(1, otlh.HISTORY_NOTICE_SENT, "2026-01-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_ACKNOWLEDGEMENT_REMINDER_SENT, "2026-01-10T00:00:00Z"),
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "acknowledged and released",
+			events: otlh.HistoryEvents{
+				custodianEvent(1, otlh.HISTORY_NOTICE_SENT, "2026-01-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_ACKNOWLEDGED, "2026-01-02T00:00:00Z"),
+				custodianEvent(2, otlh.HISTORY_RELEASED, "2026-01-02T00:00:00Z"),
+			},
+			want: []int{},
+		},
+		{
+			name: "added again after release",
+			events: otlh.HistoryEvents{
+				custodianEvent(1, otlh.HISTORY_NOTICE_SENT, "2026-01-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_ACKNOWLEDGED, "2026-01-02T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_ADDED, "2026-03-01T00:00:00Z"),
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "sent the notice again after acknowledging",
+			events: otlh.HistoryEvents{
+				custodianEvent(2, otlh.HISTORY_ACKNOWLEDGED, "2026-01-02T00:00:00Z"),
+				custodianEvent(2, otlh.HISTORY_NOTICE_SENT, "2026-03-01T00:00:00Z"),
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "acknowledged again, newest first",
+			events: otlh.HistoryEvents{
+				custodianEvent(1, otlh.HISTORY_ACKNOWLEDGED, "2026-04-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_ADDED, "2026-03-01T00:00:00Z"),
+				custodianEvent(1, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+			},
+			want: []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.events.Pending([]int{1, 2}); !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseCustodiansAddedAgain(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	released := srv.AddCustodian(otlh.Custodian{Name: "Jane Doe", Email: "jane@acme.com"})
+	readded := srv.AddCustodian(otlh.Custodian{Name: "John Doe", Email: "john@acme.com"})
+
+	hold := srv.AddLegalhold(otlh.Legalhold{Name: "Acme"}, released.ID, readded.ID)
+	srv.AddLegalholdHistory(hold.ID,
+		custodianEvent(released.ID, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+		custodianEvent(readded.ID, otlh.HISTORY_RELEASED, "2026-02-01T00:00:00Z"),
+		custodianEvent(readded.ID, otlh.HISTORY_ADDED, "2026-03-01T00:00:00Z"),
+	)
+
+	client := srv.ClientBuilder().WithRetryPolicy(otlh.RetryPolicy{MaxAttempts: 1}).Build()
+
+	if err := client.ReleaseLegalholdCustodians(hold.ID, []int{readded.ID}, false); err != nil {
+		t.Fatalf("releasing a custodian added again: %v", err)
+	}
+
+	var apiErr *otlh.APIError
+	err := client.ReleaseLegalholdCustodians(hold.ID, []int{released.ID}, false)
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 422 {
+		t.Fatalf("got error %v releasing a released custodian, want status 422", err)
+	}
+
+	history, err := client.GetLegalholdHistory(hold)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := history.Released([]int{released.ID, readded.ID}); !slices.Equal(got, []int{released.ID, readded.ID}) {
+		t.Fatalf("got released %v, want both", got)
+	}
+}
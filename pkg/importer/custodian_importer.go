@@ -1,6 +1,7 @@
 package importer
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"strings"
@@ -86,10 +87,19 @@ func (b *CustodianImporterBuilder) Build() (*CustodianImporter, error) {
 		return nil, err
 	}
 
+	for _, custodian := range b.custodians {
+		otlh.DefaultRedactor.AddName(custodian.Name)
+		otlh.DefaultRedactor.AddName(custodian.SupervisorName)
+	}
+
 	log.Debug().Msgf("custodians %+v:", b.custodians)
 	return b.CustodianImporter, nil
 }
 
 func (c *CustodianImporter) Import() error {
-	return c.client.ImportCustodians(c.custodians, c.batchSize)
+	return c.ImportContext(context.Background())
+}
+
+func (c *CustodianImporter) ImportContext(ctx context.Context) error {
+	return c.client.ImportCustodiansContext(ctx, c.custodians, c.batchSize)
 }
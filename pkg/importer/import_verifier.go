@@ -1,6 +1,8 @@
 package importer
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -25,12 +27,20 @@ func (e *ExcelImporter) verifyHeader(row []string, header []string) error {
 }
 
 func (e *ExcelImporter) VerifyCustodians() error {
+	return e.VerifyCustodiansContext(context.Background())
+}
+
+func (e *ExcelImporter) VerifyCustodiansContext(ctx context.Context) error {
 	var verr *ValidationError = newValidationError(ErrorCustodianNotFound)
 
 	for email, name := range e.collections.UniqueCustodians {
-		err := e.FindCustodianByNameAndEmail(name, email)
-		if err != nil {
+		err := e.FindCustodianByNameAndEmailContext(ctx, name, email)
+		if errors.Is(err, ErrorCustodianNotFound) {
 			verr.add(fmt.Errorf("custodian: %s email: %s not found", name, email))
+			continue
+		}
+		if err != nil {
+			return err
 		}
 	}
 
@@ -184,7 +194,7 @@ func (e *ExcelImporter) verifyHoldName() error {
 	return nil
 }
 
-func (e *ExcelImporter) baselineDataIntegrityCheck() error {
+func (e *ExcelImporter) baselineDataIntegrityCheck(ctx context.Context) error {
 	var err error
 
 	// verify the same matter under same folder
@@ -224,7 +234,7 @@ func (e *ExcelImporter) baselineDataIntegrityCheck() error {
 	}
 
 	log.Debug().Msg("Verify custodians ...")
-	if err = e.VerifyCustodians(); err != nil {
+	if err = e.VerifyCustodiansContext(ctx); err != nil {
 		return err
 	}
 
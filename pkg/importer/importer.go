@@ -1,6 +1,9 @@
 package importer
 
 import (
+	"context"
+	"errors"
+
 	"github.com/rs/zerolog/log"
 	otlh "github.com/xifanyan/otlh/pkg"
 )
@@ -54,6 +57,8 @@ type ExcelImporter struct {
 	client              *otlh.Client
 	timezone            string
 	entries             HoldEntries
+	prefetch            bool
+	index               *LookupIndex
 }
 
 func NewExcelImporter() *ExcelImporter {
@@ -95,6 +100,16 @@ func (e *ExcelImporter) WithHoldName(name string) *ExcelImporter {
 	return e
 }
 
+/*
+WithPrefetch makes the importer load all custodians, folders and matters once,
+see LookupIndex, instead of looking them up one request at a time. It pays off
+for large workbooks.
+*/
+func (e *ExcelImporter) WithPrefetch(prefetch bool) *ExcelImporter {
+	e.prefetch = prefetch
+	return e
+}
+
 func (e *ExcelImporter) Legalhold() *LegalholdExcelImporter {
 	return &LegalholdExcelImporter{
 		ExcelImporter: *e,
@@ -113,28 +128,48 @@ func (e *ExcelImporter) Silenthold() *SilentholdExcelImporter {
 // - name: the name of the custodian to find
 // - email: the email of the custodian to find
 // Return type:
-// - error: any error that occurred during the search
+// - error: ErrorCustodianNotFound if there is no such custodian, or any error that occurred during the search
 func (e *ExcelImporter) FindCustodianByNameAndEmail(name, email string) error {
+	return e.FindCustodianByNameAndEmailContext(context.Background(), name, email)
+}
+
+// FindCustodianByNameAndEmailContext is like FindCustodianByNameAndEmail but carries ctx.
+func (e *ExcelImporter) FindCustodianByNameAndEmailContext(ctx context.Context, name, email string) error {
 	var err error
 
-	req, _ := otlh.NewRequest().WithTenant(e.client.Tenant()).Get().Custodian().Build()
-	opts := otlh.NewListOptions().WithFilterName(name)
-	custodians, err := e.client.GetCustodians(req, opts)
+	if e.prefetch {
+		var ix *LookupIndex
+		if ix, err = e.lookupIndex(ctx); err != nil {
+			return err
+		}
+		_, err = ix.FindCustodianByNameAndEmail(name, email)
+	} else {
+		_, err = e.client.FindCustodianByNameAndEmailContext(ctx, name, email)
+	}
+
+	if errors.Is(err, otlh.ErrNotFound) {
+		return ErrorCustodianNotFound
+	}
 	if err != nil {
 		return err
 	}
 
-	for _, custodian := range custodians {
-		if (custodian.Name == name) && (custodian.Email == email) {
-			log.Debug().Msgf("- [found] %s - %s", name, email)
-			return nil
-		}
+	log.Debug().Msgf("- [found] %s - %s", name, email)
+	return nil
+}
+
+// lookupIndex loads the lookup index on first use.
+func (e *ExcelImporter) lookupIndex(ctx context.Context) (*LookupIndex, error) {
+	var err error
+
+	if e.index == nil {
+		e.index, err = LoadLookupIndex(ctx, e.client)
 	}
 
-	return ErrorCustodianNotFound
+	return e.index, err
 }
 
-func (e *ExcelImporter) getFolderID(name string) (int, error) {
+func (e *ExcelImporter) getFolderID(ctx context.Context, name string) (int, error) {
 	var err error
 	var folder otlh.Folder
 
@@ -143,24 +178,85 @@ func (e *ExcelImporter) getFolderID(name string) (int, error) {
 		return folderID, nil
 	}
 
-	if folder, err = e.client.FindOrCreateFolder(name); err != nil {
+	if e.prefetch {
+		folder, err = e.findOrCreateIndexedFolder(ctx, name)
+	} else {
+		folder, err = e.client.FindOrCreateFolderContext(ctx, name)
+	}
+
+	if err != nil {
 		return 0, err
 	}
 	return folder.ID, nil
 }
 
-func (e *ExcelImporter) getMatterID(name string) (int, error) {
+func (e *ExcelImporter) getMatterID(ctx context.Context, name string) (int, error) {
 	var err error
 	var matter otlh.Matter
 	var folderID int
 
-	if folderID, err = e.getFolderID(e.collections.MatterToFolderMap[name]); err != nil {
+	if folderID, err = e.getFolderID(ctx, e.collections.MatterToFolderMap[name]); err != nil {
 		return 0, err
 	}
 
-	if matter, err = e.client.FindOrCreateMatter(name, folderID); err != nil {
+	if e.prefetch {
+		matter, err = e.findOrCreateIndexedMatter(ctx, name, folderID)
+	} else {
+		matter, err = e.client.FindOrCreateMatterContext(ctx, name, folderID)
+	}
+
+	if err != nil {
 		return 0, err
 	}
 
 	return matter.ID, nil
 }
+
+// findOrCreateIndexedFolder is FindOrCreateFolder against the lookup index, created folders are added to it.
+func (e *ExcelImporter) findOrCreateIndexedFolder(ctx context.Context, name string) (otlh.Folder, error) {
+	ix, err := e.lookupIndex(ctx)
+	if err != nil {
+		return otlh.Folder{}, err
+	}
+
+	folder, err := ix.FindFolderByName(name)
+	if !errors.Is(err, otlh.ErrNotFound) {
+		return folder, err
+	}
+
+	log.Debug().Msgf("folder [%s] not found, creating", name)
+
+	group, err := e.client.FindGroupByNameContext(ctx, "All Admins")
+	if err != nil {
+		return otlh.Folder{}, err
+	}
+
+	if folder, err = e.client.CreateFolderContext(ctx, name, []int{group.ID}); err != nil {
+		return otlh.Folder{}, err
+	}
+
+	ix.AddFolder(folder)
+	return folder, nil
+}
+
+// findOrCreateIndexedMatter is FindOrCreateMatter against the lookup index, created matters are added to it.
+func (e *ExcelImporter) findOrCreateIndexedMatter(ctx context.Context, name string, folderID int) (otlh.Matter, error) {
+	ix, err := e.lookupIndex(ctx)
+	if err != nil {
+		return otlh.Matter{}, err
+	}
+
+	matter, err := ix.FindMatterInFolder(name, folderID)
+	if !errors.Is(err, otlh.ErrNotFound) {
+		return matter, err
+	}
+
+	log.Debug().Msgf("matter [%s] not found, creating", name)
+
+	if matter, err = e.client.CreateMatterContext(ctx, name, folderID); err != nil {
+		return otlh.Matter{}, err
+	}
+
+	ix.AddMatter(matter, folderID)
+	return matter, nil
+}
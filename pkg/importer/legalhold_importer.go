@@ -1,6 +1,8 @@
 package importer
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -36,6 +38,7 @@ func (imptr *LegalholdExcelImporter) collect(entry HoldEntry) {
 
 	if _, ok := imptr.collections.UniqueCustodians[entry.CustodianEmail]; !ok {
 		imptr.collections.UniqueCustodians[entry.CustodianEmail] = entry.CustodianName
+		otlh.DefaultRedactor.AddName(entry.CustodianName)
 	}
 
 	if _, ok := imptr.collections.MatterToFolderMap[entry.MatterName]; !ok {
@@ -161,6 +164,16 @@ func (imptr *LegalholdExcelImporter) transformToLegalholdDetails() (LegalholdDet
 }
 
 func (imptr *LegalholdExcelImporter) Import() error {
+	return imptr.ImportContext(context.Background())
+}
+
+/*
+ImportContext imports every hold loaded from the excel file. When ctx is
+done the import stops before the next hold and ctx.Err() is returned; holds
+that were already imported are logged.
+*/
+func (imptr *LegalholdExcelImporter) ImportContext(ctx context.Context) error {
+	var imported int
 
 	log.Debug().Msg("[Start]: Importing Legalholds from Excel")
 
@@ -173,24 +186,34 @@ func (imptr *LegalholdExcelImporter) Import() error {
 		var tmpDir string
 		var matterID int
 
+		if ctx.Err() != nil {
+			log.Info().Msgf("interrupted: imported %d of %d legalholds", imported, len(legalholdDetails))
+			return ctx.Err()
+		}
+
 		log.Debug().Msgf("[Processing]: Matter %s, Hold: %s, # of custodians: %d",
 			legalholdDetail.LegalholdInfo.MatterName,
 			legalholdDetail.LegalholdInfo.HoldName,
 			len(legalholdDetail.CustodianDetails),
 		)
 
-		if matterID, err = imptr.getMatterID(legalholdDetail.LegalholdInfo.MatterName); err != nil {
-			log.Error().Msgf("[%s - %s] not able to get matter id", legalholdDetail.LegalholdInfo.MatterName, legalholdDetail.LegalholdInfo.HoldName)
+		if matterID, err = imptr.getMatterID(ctx, legalholdDetail.LegalholdInfo.MatterName); err != nil {
+			log.Error().Msgf("[%s - %s] not able to get matter id: %s", legalholdDetail.LegalholdInfo.MatterName, legalholdDetail.LegalholdInfo.HoldName, err)
 			continue
 		}
 		legalholdDetail.LegalholdInfo.MatterID = fmt.Sprintf("%d", matterID)
 
-		_, err := imptr.client.FindLegalhold(legalholdDetail.LegalholdInfo.HoldName, matterID)
+		_, err := imptr.client.FindLegalholdContext(ctx, legalholdDetail.LegalholdInfo.HoldName, matterID)
 		if err == nil {
 			log.Error().Msgf("[%s - %s] already exists", legalholdDetail.LegalholdInfo.MatterName, legalholdDetail.LegalholdInfo.HoldName)
 			continue
 		}
 
+		if !errors.Is(err, otlh.ErrNotFound) {
+			log.Error().Msgf("[%s - %s] not able to check if hold exists: %s", legalholdDetail.LegalholdInfo.MatterName, legalholdDetail.LegalholdInfo.HoldName, err)
+			continue
+		}
+
 		if tmpDir, err = os.MkdirTemp("", "legalhold_"); err != nil {
 			log.Error().Msgf("not able to create temp dir [%s - %s]", legalholdDetail.LegalholdInfo.MatterName, legalholdDetail.LegalholdInfo.HoldName)
 			continue
@@ -217,14 +240,16 @@ func (imptr *LegalholdExcelImporter) Import() error {
 		}
 
 		log.Debug().Msgf("Importing legalhold - [%s - %s]", legalholdDetail.LegalholdInfo.MatterName, legalholdDetail.LegalholdInfo.HoldName)
-		_, err = imptr.client.ImportLegalhold(tmpDir + "/legal_hold_details.zip")
+		_, err = imptr.client.ImportLegalholdContext(ctx, tmpDir+"/legal_hold_details.zip")
 		if err != nil {
 			log.Error().Msgf("legalhold import failed %s - [%s - %s]", err, legalholdDetail.LegalholdInfo.MatterName, legalholdDetail.LegalholdInfo.HoldName)
 			continue
 		}
 
+		imported++
 	}
 
+	log.Info().Msgf("imported %d of %d legalholds", imported, len(legalholdDetails))
 	log.Debug().Msg("[End]: Finished Importing Legalholds")
 
 	return nil
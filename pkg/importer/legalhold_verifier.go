@@ -1,6 +1,7 @@
 package importer
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -27,9 +28,14 @@ func (imptr *LegalholdExcelImporter) verifyAttachmentFiles() error {
 }
 
 func (imptr *LegalholdExcelImporter) PerformDataIntegrityCheck() error {
+	return imptr.PerformDataIntegrityCheckContext(context.Background())
+}
+
+// PerformDataIntegrityCheckContext is like PerformDataIntegrityCheck but carries ctx.
+func (imptr *LegalholdExcelImporter) PerformDataIntegrityCheckContext(ctx context.Context) error {
 	var err error
 
-	if err = imptr.baselineDataIntegrityCheck(); err != nil {
+	if err = imptr.baselineDataIntegrityCheck(ctx); err != nil {
 		return err
 	}
 
@@ -0,0 +1,130 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	otlh "github.com/xifanyan/otlh/pkg"
+)
+
+type custodianKey struct {
+	name  string
+	email string
+}
+
+type matterKey struct {
+	name     string
+	folderID int
+}
+
+/*
+LookupIndex holds every custodian, folder and matter of the tenant, loaded once
+with the GetAll* calls. The Excel importers use it, when prefetching is enabled,
+to verify custodians and resolve folder and matter ids in memory instead of
+sending a request per custodian and per hold.
+
+Lookups follow the same rules as the client's Find* helpers: exact names, an
+error wrapping otlh.ErrNotFound when nothing matches, one wrapping
+otlh.ErrAmbiguous when several entities do and, for matters, one wrapping
+otlh.ErrNoFolderLink when a matter with the name has no folder link.
+*/
+type LookupIndex struct {
+	custodians map[custodianKey][]otlh.Custodian
+	folders    map[string][]otlh.Folder
+	matters    map[matterKey][]otlh.Matter
+
+	// matters without a folder link, by name
+	unfiled map[string][]otlh.Matter
+}
+
+func NewLookupIndex() *LookupIndex {
+	return &LookupIndex{
+		custodians: make(map[custodianKey][]otlh.Custodian),
+		folders:    make(map[string][]otlh.Folder),
+		matters:    make(map[matterKey][]otlh.Matter),
+		unfiled:    make(map[string][]otlh.Matter),
+	}
+}
+
+// LoadLookupIndex fetches all custodians, folders and matters of the client's tenant.
+func LoadLookupIndex(ctx context.Context, client *otlh.Client) (*LookupIndex, error) {
+	var err error
+	var custodians otlh.Custodians
+	var folders otlh.Folders
+	var matters otlh.Matters
+
+	ix := NewLookupIndex()
+	tenant := client.Tenant()
+
+	log.Info().Msg("Prefetching custodians ...")
+	custodianReq, _ := otlh.NewRequest().WithTenant(tenant).Get().Custodian().Build()
+	if custodians, err = client.GetAllCustodiansContext(ctx, custodianReq, otlh.NewListOptions().WithPageSize(otlh.FIND_PAGE_SIZE)); err != nil {
+		return nil, fmt.Errorf("prefetch custodians: %w", err)
+	}
+
+	log.Info().Msg("Prefetching folders ...")
+	folderReq, _ := otlh.NewRequest().WithTenant(tenant).Get().Folder().Build()
+	if folders, err = client.GetAllFoldersContext(ctx, folderReq, otlh.NewListOptions().WithPageSize(otlh.FIND_PAGE_SIZE)); err != nil {
+		return nil, fmt.Errorf("prefetch folders: %w", err)
+	}
+
+	log.Info().Msg("Prefetching matters ...")
+	matterReq, _ := otlh.NewRequest().WithTenant(tenant).Get().Matter().Build()
+	if matters, err = client.GetAllMattersContext(ctx, matterReq, otlh.NewListOptions().WithPageSize(otlh.FIND_PAGE_SIZE)); err != nil {
+		return nil, fmt.Errorf("prefetch matters: %w", err)
+	}
+
+	for _, custodian := range custodians {
+		ix.AddCustodian(custodian)
+	}
+	for _, folder := range folders {
+		ix.AddFolder(folder)
+	}
+	for _, matter := range matters {
+		ix.AddMatter(matter, matter.FolderID())
+	}
+
+	log.Info().Msgf("Prefetched %d custodians, %d folders, %d matters", len(custodians), len(folders), len(matters))
+
+	return ix, nil
+}
+
+func (ix *LookupIndex) AddCustodian(custodian otlh.Custodian) {
+	key := custodianKey{name: custodian.Name, email: custodian.Email}
+	ix.custodians[key] = append(ix.custodians[key], custodian)
+}
+
+func (ix *LookupIndex) AddFolder(folder otlh.Folder) {
+	ix.folders[folder.Name] = append(ix.folders[folder.Name], folder)
+}
+
+// AddMatter indexes matter under the folder with folderID, 0 if its folder is not known.
+func (ix *LookupIndex) AddMatter(matter otlh.Matter, folderID int) {
+	if folderID == 0 {
+		ix.unfiled[matter.Name] = append(ix.unfiled[matter.Name], matter)
+		return
+	}
+
+	key := matterKey{name: matter.Name, folderID: folderID}
+	ix.matters[key] = append(ix.matters[key], matter)
+}
+
+func (ix *LookupIndex) FindCustodianByNameAndEmail(name, email string) (otlh.Custodian, error) {
+	custodians := ix.custodians[custodianKey{name: name, email: email}]
+	return otlh.ExactlyOne(custodians, "custodian", fmt.Sprintf("%s <%s>", name, email), func(custodian otlh.Custodian) int { return custodian.ID })
+}
+
+func (ix *LookupIndex) FindFolderByName(name string) (otlh.Folder, error) {
+	return otlh.ExactlyOne(ix.folders[name], "folder", name, func(folder otlh.Folder) int { return folder.ID })
+}
+
+func (ix *LookupIndex) FindMatterInFolder(name string, folderID int) (otlh.Matter, error) {
+	// a matter without a folder link may be the one in folderID
+	if unfiled := ix.unfiled[name]; len(unfiled) > 0 {
+		return otlh.Matter{}, fmt.Errorf("matter [%s] with id %d %w", name, unfiled[0].ID, otlh.ErrNoFolderLink)
+	}
+
+	matters := ix.matters[matterKey{name: name, folderID: folderID}]
+	return otlh.ExactlyOne(matters, "matter", name, func(matter otlh.Matter) int { return matter.ID })
+}
@@ -0,0 +1,131 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestLookupIndex(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	jane := srv.AddCustodian(otlh.Custodian{Name: "Jane Doe", Email: "jane@acme.com"})
+	srv.AddCustodian(otlh.Custodian{Name: "John Doe", Email: "john@acme.com"})
+	srv.AddCustodian(otlh.Custodian{Name: "John Doe", Email: "john@acme.com"})
+
+	acme := srv.AddFolder(otlh.Folder{Name: "Acme"})
+	srv.AddFolder(otlh.Folder{Name: "Twin"})
+	srv.AddFolder(otlh.Folder{Name: "Twin"})
+
+	matter := srv.AddMatter(otlh.Matter{Name: "Contract"}, acme.ID)
+	srv.AddMatter(otlh.Matter{Name: "Dispute"}, acme.ID)
+	srv.AddMatter(otlh.Matter{Name: "Dispute"}, acme.ID)
+	srv.AddMatter(otlh.Matter{Name: "Unfiled"}, 0)
+
+	ix, err := LoadLookupIndex(context.Background(), srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		find    func() (int, error)
+		want    int
+		wantErr error
+	}{
+		{
+			name: "custodian",
+			find: func() (int, error) {
+				c, err := ix.FindCustodianByNameAndEmail("Jane Doe", "jane@acme.com")
+				return c.ID, err
+			},
+			want: jane.ID,
+		},
+		{
+			name: "custodian with another email",
+			find: func() (int, error) {
+				c, err := ix.FindCustodianByNameAndEmail("Jane Doe", "jane@other.com")
+				return c.ID, err
+			},
+			wantErr: otlh.ErrNotFound,
+		},
+		{
+			name: "duplicate custodian",
+			find: func() (int, error) {
+				c, err := ix.FindCustodianByNameAndEmail("John Doe", "john@acme.com")
+				return c.ID, err
+			},
+			wantErr: otlh.ErrAmbiguous,
+		},
+		{
+			name: "folder",
+			find: func() (int, error) {
+				f, err := ix.FindFolderByName("Acme")
+				return f.ID, err
+			},
+			want: acme.ID,
+		},
+		{
+			name: "duplicate folder",
+			find: func() (int, error) {
+				f, err := ix.FindFolderByName("Twin")
+				return f.ID, err
+			},
+			wantErr: otlh.ErrAmbiguous,
+		},
+		{
+			name: "matter",
+			find: func() (int, error) {
+				m, err := ix.FindMatterInFolder("Contract", acme.ID)
+				return m.ID, err
+			},
+			want: matter.ID,
+		},
+		{
+			name: "matter in another folder",
+			find: func() (int, error) {
+				m, err := ix.FindMatterInFolder("Contract", acme.ID+100)
+				return m.ID, err
+			},
+			wantErr: otlh.ErrNotFound,
+		},
+		{
+			name: "duplicate matter",
+			find: func() (int, error) {
+				m, err := ix.FindMatterInFolder("Dispute", acme.ID)
+				return m.ID, err
+			},
+			wantErr: otlh.ErrAmbiguous,
+		},
+		{
+			name: "matter without a folder link",
+			find: func() (int, error) {
+				m, err := ix.FindMatterInFolder("Unfiled", acme.ID)
+				return m.ID, err
+			},
+			wantErr: otlh.ErrNoFolderLink,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := tt.find()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != tt.want {
+				t.Fatalf("got id %d, want %d", id, tt.want)
+			}
+		})
+	}
+}
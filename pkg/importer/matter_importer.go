@@ -1,6 +1,7 @@
 package importer
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
@@ -59,6 +60,11 @@ func (e *MatterImporter) verifyHeader(row []string, header []string) error {
 }
 
 func (imptr *MatterImporter) LoadMatterData() error {
+	return imptr.LoadMatterDataContext(context.Background())
+}
+
+// LoadMatterDataContext is like LoadMatterData but carries ctx.
+func (imptr *MatterImporter) LoadMatterDataContext(ctx context.Context) error {
 	var rows [][]string
 
 	log.Info().Msg("Importing matters from " + imptr.excel)
@@ -92,7 +98,7 @@ func (imptr *MatterImporter) LoadMatterData() error {
 				data[i] = strings.TrimSpace(data[i])
 			}
 
-			matter, err := imptr.client.FindMatterByName(data[0])
+			matter, err := imptr.client.FindMatterByNameContext(ctx, data[0])
 			if err != nil {
 				return err
 			}
@@ -131,8 +137,13 @@ func (imptr *MatterImporter) LoadMatterData() error {
 }
 
 func (imptr *MatterImporter) Import() error {
+	return imptr.ImportContext(context.Background())
+}
+
+// ImportContext is like Import but stops before the next matter once ctx is done.
+func (imptr *MatterImporter) ImportContext(ctx context.Context) error {
 	log.Debug().Msg("[Start]: Importing Matters from Excel")
-	err := imptr.LoadMatterData()
+	err := imptr.LoadMatterDataContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -141,12 +152,12 @@ func (imptr *MatterImporter) Import() error {
 		return err
 	}
 
-	for _, entry := range imptr.entries {
+	for i, entry := range imptr.entries {
 		log.Debug().Msgf("Matter Input: %+v", entry)
 
-		matter, err := imptr.client.ImportMatter(entry)
+		matter, err := imptr.client.ImportMatterContext(ctx, entry)
 		if err != nil {
-			return err
+			return fmt.Errorf("imported %d of %d matters: %w", i, len(imptr.entries), err)
 		}
 		log.Debug().Msgf("Matter Output: %+v", matter)
 	}
@@ -1,6 +1,8 @@
 package importer
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -27,6 +29,7 @@ func (imptr *SilentholdExcelImporter) collect(entry HoldEntry) {
 
 	if _, ok := imptr.collections.UniqueCustodians[entry.CustodianEmail]; !ok {
 		imptr.collections.UniqueCustodians[entry.CustodianEmail] = entry.CustodianName
+		otlh.DefaultRedactor.AddName(entry.CustodianName)
 	}
 
 	if _, ok := imptr.collections.MatterToFolderMap[entry.MatterName]; !ok {
@@ -137,6 +140,16 @@ func (imptr *SilentholdExcelImporter) transformToSilentholdDetails() (Silenthold
 }
 
 func (imptr *SilentholdExcelImporter) Import() error {
+	return imptr.ImportContext(context.Background())
+}
+
+/*
+ImportContext imports every hold loaded from the excel file. When ctx is
+done the import stops before the next hold and ctx.Err() is returned; holds
+that were already imported are logged.
+*/
+func (imptr *SilentholdExcelImporter) ImportContext(ctx context.Context) error {
+	var imported int
 
 	log.Debug().Msg("[Start]: Importing Legalholds from Excel")
 
@@ -149,24 +162,34 @@ func (imptr *SilentholdExcelImporter) Import() error {
 		var tmpDir string
 		var matterID int
 
+		if ctx.Err() != nil {
+			log.Info().Msgf("interrupted: imported %d of %d silentholds", imported, len(silentholdDetails))
+			return ctx.Err()
+		}
+
 		log.Debug().Msgf("[Processing]: Matter %s, Hold: %s, # of custodians: %d",
 			silentholdDetail.SilentholdInfo.MatterName,
 			silentholdDetail.SilentholdInfo.HoldName,
 			len(silentholdDetail.CustodianDetails),
 		)
 
-		if matterID, err = imptr.getMatterID(silentholdDetail.SilentholdInfo.MatterName); err != nil {
-			log.Error().Msgf("[%s - %s] not able to get matter id", silentholdDetail.SilentholdInfo.MatterName, silentholdDetail.SilentholdInfo.HoldName)
+		if matterID, err = imptr.getMatterID(ctx, silentholdDetail.SilentholdInfo.MatterName); err != nil {
+			log.Error().Msgf("[%s - %s] not able to get matter id: %s", silentholdDetail.SilentholdInfo.MatterName, silentholdDetail.SilentholdInfo.HoldName, err)
 			continue
 		}
 		silentholdDetail.SilentholdInfo.MatterID = fmt.Sprintf("%d", matterID)
 
-		_, err := imptr.client.FindSilenthold(silentholdDetail.SilentholdInfo.HoldName, matterID)
+		_, err := imptr.client.FindSilentholdContext(ctx, silentholdDetail.SilentholdInfo.HoldName, matterID)
 		if err == nil {
 			log.Error().Msgf("[%s - %s] already exists", silentholdDetail.SilentholdInfo.MatterName, silentholdDetail.SilentholdInfo.HoldName)
 			continue
 		}
 
+		if !errors.Is(err, otlh.ErrNotFound) {
+			log.Error().Msgf("[%s - %s] not able to check if hold exists: %s", silentholdDetail.SilentholdInfo.MatterName, silentholdDetail.SilentholdInfo.HoldName, err)
+			continue
+		}
+
 		if tmpDir, err = os.MkdirTemp("", "silenthold_"); err != nil {
 			log.Error().Msgf("not able to create temp dir [%s - %s]", silentholdDetail.SilentholdInfo.MatterName, silentholdDetail.SilentholdInfo.HoldName)
 			continue
@@ -187,14 +210,16 @@ func (imptr *SilentholdExcelImporter) Import() error {
 		}
 
 		log.Debug().Msgf("Importing silenthold - [%s - %s]", silentholdDetail.SilentholdInfo.MatterName, silentholdDetail.SilentholdInfo.HoldName)
-		_, err = imptr.client.ImportSilenthold(tmpDir + "/silent_hold_details.zip")
+		_, err = imptr.client.ImportSilentholdContext(ctx, tmpDir+"/silent_hold_details.zip")
 		if err != nil {
 			log.Error().Msgf("silenthold import failed %s - [%s - %s]", err, silentholdDetail.SilentholdInfo.MatterName, silentholdDetail.SilentholdInfo.HoldName)
 			continue
 		}
 
+		imported++
 	}
 
+	log.Info().Msgf("imported %d of %d silentholds", imported, len(silentholdDetails))
 	log.Debug().Msg("[End]: Finished Importing Silentholds")
 
 	return nil
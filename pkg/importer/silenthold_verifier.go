@@ -1,13 +1,19 @@
 package importer
 
 import (
+	"context"
 	"github.com/rs/zerolog/log"
 )
 
 func (imptr *SilentholdExcelImporter) PerformDataIntegrityCheck() error {
+	return imptr.PerformDataIntegrityCheckContext(context.Background())
+}
+
+// PerformDataIntegrityCheckContext is like PerformDataIntegrityCheck but carries ctx.
+func (imptr *SilentholdExcelImporter) PerformDataIntegrityCheckContext(ctx context.Context) error {
 	var err error
 
-	if err = imptr.baselineDataIntegrityCheck(); err != nil {
+	if err = imptr.baselineDataIntegrityCheck(ctx); err != nil {
 		return err
 	}
 
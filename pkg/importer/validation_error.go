@@ -2,7 +2,10 @@ package importer
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+
+	otlh "github.com/xifanyan/otlh/pkg"
 )
 
 var (
@@ -12,7 +15,7 @@ var (
 	ErrorAttachmentFileNotFound                        = errors.New("attachment file not found")
 	ErrorInvalidEmailAddress                           = errors.New("invalid email address")
 	ErrorHoldNameTooLong                               = errors.New("hold name too long")
-	ErrorCustodianNotFound                             = errors.New("custodian not found")
+	ErrorCustodianNotFound                             = fmt.Errorf("custodian %w", otlh.ErrNotFound)
 )
 
 type ValidationError struct {
@@ -60,6 +60,9 @@ type Action int
 const (
 	IMPORT Action = iota + 1
 	SEND_NOTICE
+	SEND_ACKNOWLEDGEMENT_REMINDERS
+	SEND_HOLD_REMINDERS
+	RELEASE_CUSTODIANS
 )
 
 type LegalholdRequestBuilder struct {
@@ -93,6 +96,21 @@ func (b *LegalholdRequestBuilder) SendNotice() *LegalholdRequestBuilder {
 	return b
 }
 
+func (b *LegalholdRequestBuilder) SendAcknowledgementReminders() *LegalholdRequestBuilder {
+	b.action = SEND_ACKNOWLEDGEMENT_REMINDERS
+	return b
+}
+
+func (b *LegalholdRequestBuilder) SendHoldReminders() *LegalholdRequestBuilder {
+	b.action = SEND_HOLD_REMINDERS
+	return b
+}
+
+func (b *LegalholdRequestBuilder) ReleaseCustodians() *LegalholdRequestBuilder {
+	b.action = RELEASE_CUSTODIANS
+	return b
+}
+
 func (b *LegalholdRequestBuilder) Build() (*LegalholdRequest, error) {
 	return b.LegalholdRequest, nil
 }
@@ -103,6 +121,12 @@ func (req *LegalholdRequest) Endpoint() string {
 		return fmt.Sprintf("/t/%s/api/%s/legal_holds/import", req.tenant, APIVERSION)
 	case SEND_NOTICE:
 		return fmt.Sprintf("/t/%s/api/%s/legal_holds/send_notice", req.tenant, APIVERSION)
+	case SEND_ACKNOWLEDGEMENT_REMINDERS:
+		return fmt.Sprintf("/t/%s/api/%s/legal_holds/send_acknowledgement_reminders", req.tenant, APIVERSION)
+	case SEND_HOLD_REMINDERS:
+		return fmt.Sprintf("/t/%s/api/%s/legal_holds/send_hold_reminders", req.tenant, APIVERSION)
+	case RELEASE_CUSTODIANS:
+		return fmt.Sprintf("/t/%s/api/%s/legal_holds/release_custodians", req.tenant, APIVERSION)
 	}
 
 	if req.id > 0 {
@@ -0,0 +1,226 @@
+package otlh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+/*
+linkPath returns the path and query of href. The service returns _links as
+paths (/t/<tenant>/api/v3/...), absolute URLs are reduced to their path so
+the request still goes through the client's base URL, proxy and TLS settings.
+*/
+func linkPath(href string) (string, error) {
+	if href == "" {
+		return "", fmt.Errorf("link %w", ErrNotFound)
+	}
+
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", fmt.Errorf("invalid link %s: %w", href, err)
+	}
+	return u.RequestURI(), nil
+}
+
+/*
+Follow gets the target of a _links href, e.g. legalhold.Links.Stats.Href, and
+unmarshals it into into. A paginated target is fetched page by page and
+merged like GetAllPages does, so into sees every item under _embedded.
+
+Follow reaches related data that has no typed method, see the
+Get<Entity><Link> helpers below for the common links.
+*/
+func (c *Client) Follow(href string, into any) error {
+	return c.FollowContext(context.Background(), href, into)
+}
+
+// FollowContext is like Follow but carries ctx.
+func (c *Client) FollowContext(ctx context.Context, href string, into any) error {
+	path, err := linkPath(href)
+	if err != nil {
+		return err
+	}
+
+	req := NewRawRequest(GET, c.tenant, path)
+
+	// no paging parameters on the first request, the target may not be a list
+	body, err := c.SendContext(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	pages := []json.RawMessage{body}
+	_, hasMore, total, err := unmarshalRawPage(body)
+	if err == nil && hasMore {
+		err = walkEntitiesFrom(ctx, c, req, NewListOptions(), 2, unmarshalRawPage, func(page []json.RawMessage, totalCount int) bool {
+			pages = append(pages, page...)
+			total = totalCount
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		if body, err = mergePages(pages, total); err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(body, into)
+}
+
+/*
+followList follows href to a list of entities of type T, which the service
+embeds under key, and returns all of them.
+*/
+func followList[T any](ctx context.Context, c *Client, href string, key string) ([]T, error) {
+	var resp struct {
+		Embedded map[string]json.RawMessage `json:"_embedded"`
+	}
+
+	if err := c.FollowContext(ctx, href, &resp); err != nil {
+		return nil, err
+	}
+
+	var entities []T
+	if data, ok := resp.Embedded[key]; ok {
+		if err := json.Unmarshal(data, &entities); err != nil {
+			return nil, err
+		}
+	}
+	return entities, nil
+}
+
+/*
+The Get<Entity><Link> helpers below follow one of an entity's _links to the
+typed entities behind it, e.g. GetMatterLegalholds follows matter.Links.LegalHolds.
+Lists are fetched completely, page by page.
+*/
+
+// GetLegalholdCustodians returns the custodians of a legal hold.
+func (c *Client) GetLegalholdCustodians(legalhold Legalhold) (Custodians, error) {
+	return c.GetLegalholdCustodiansContext(context.Background(), legalhold)
+}
+
+// GetLegalholdCustodiansContext is like GetLegalholdCustodians but carries ctx.
+func (c *Client) GetLegalholdCustodiansContext(ctx context.Context, legalhold Legalhold) (Custodians, error) {
+	return followList[Custodian](ctx, c, legalhold.Links.Custodians.Href, "custodians")
+}
+
+// GetLegalholdMatter returns the matter of a legal hold.
+func (c *Client) GetLegalholdMatter(legalhold Legalhold) (Matter, error) {
+	return c.GetLegalholdMatterContext(context.Background(), legalhold)
+}
+
+// GetLegalholdMatterContext is like GetLegalholdMatter but carries ctx.
+func (c *Client) GetLegalholdMatterContext(ctx context.Context, legalhold Legalhold) (Matter, error) {
+	var matter Matter
+	err := c.FollowContext(ctx, legalhold.Links.Matter.Href, &matter)
+	return matter, err
+}
+
+// GetSilentholdCustodians returns the custodians of a silent hold.
+func (c *Client) GetSilentholdCustodians(silenthold Silenthold) (Custodians, error) {
+	return c.GetSilentholdCustodiansContext(context.Background(), silenthold)
+}
+
+// GetSilentholdCustodiansContext is like GetSilentholdCustodians but carries ctx.
+func (c *Client) GetSilentholdCustodiansContext(ctx context.Context, silenthold Silenthold) (Custodians, error) {
+	return followList[Custodian](ctx, c, silenthold.Links.Custodians.Href, "custodians")
+}
+
+// GetSilentholdMatter returns the matter of a silent hold.
+func (c *Client) GetSilentholdMatter(silenthold Silenthold) (Matter, error) {
+	return c.GetSilentholdMatterContext(context.Background(), silenthold)
+}
+
+// GetSilentholdMatterContext is like GetSilentholdMatter but carries ctx.
+func (c *Client) GetSilentholdMatterContext(ctx context.Context, silenthold Silenthold) (Matter, error) {
+	var matter Matter
+	err := c.FollowContext(ctx, silenthold.Links.Matter.Href, &matter)
+	return matter, err
+}
+
+// GetMatterLegalholds returns the legal holds of a matter.
+func (c *Client) GetMatterLegalholds(matter Matter) (Legalholds, error) {
+	return c.GetMatterLegalholdsContext(context.Background(), matter)
+}
+
+// GetMatterLegalholdsContext is like GetMatterLegalholds but carries ctx.
+func (c *Client) GetMatterLegalholdsContext(ctx context.Context, matter Matter) (Legalholds, error) {
+	return followList[Legalhold](ctx, c, matter.Links.LegalHolds.Href, "legal_holds")
+}
+
+// GetMatterCustodians returns the custodians of a matter, those of all its holds included.
+func (c *Client) GetMatterCustodians(matter Matter) (Custodians, error) {
+	return c.GetMatterCustodiansContext(context.Background(), matter)
+}
+
+// GetMatterCustodiansContext is like GetMatterCustodians but carries ctx.
+func (c *Client) GetMatterCustodiansContext(ctx context.Context, matter Matter) (Custodians, error) {
+	return followList[Custodian](ctx, c, matter.Links.Custodians.Href, "custodians")
+}
+
+// GetMatterFolder returns the folder a matter belongs to.
+func (c *Client) GetMatterFolder(matter Matter) (Folder, error) {
+	return c.GetMatterFolderContext(context.Background(), matter)
+}
+
+// GetMatterFolderContext is like GetMatterFolder but carries ctx.
+func (c *Client) GetMatterFolderContext(ctx context.Context, matter Matter) (Folder, error) {
+	var folder Folder
+	err := c.FollowContext(ctx, matter.Links.Folder.Href, &folder)
+	return folder, err
+}
+
+// GetFolderMatters returns the matters of a folder.
+func (c *Client) GetFolderMatters(folder Folder) (Matters, error) {
+	return c.GetFolderMattersContext(context.Background(), folder)
+}
+
+// GetFolderMattersContext is like GetFolderMatters but carries ctx.
+func (c *Client) GetFolderMattersContext(ctx context.Context, folder Folder) (Matters, error) {
+	return followList[Matter](ctx, c, folder.Links.Matters.Href, "matters")
+}
+
+// GetFolderGroups returns the groups having access to a folder.
+func (c *Client) GetFolderGroups(folder Folder) (Groups, error) {
+	return c.GetFolderGroupsContext(context.Background(), folder)
+}
+
+// GetFolderGroupsContext is like GetFolderGroups but carries ctx.
+func (c *Client) GetFolderGroupsContext(ctx context.Context, folder Folder) (Groups, error) {
+	return followList[Group](ctx, c, folder.Links.Groups.Href, "groups")
+}
+
+// GetCustodianLegalholds returns the legal holds a custodian is on.
+func (c *Client) GetCustodianLegalholds(custodian Custodian) (Legalholds, error) {
+	return c.GetCustodianLegalholdsContext(context.Background(), custodian)
+}
+
+// GetCustodianLegalholdsContext is like GetCustodianLegalholds but carries ctx.
+func (c *Client) GetCustodianLegalholdsContext(ctx context.Context, custodian Custodian) (Legalholds, error) {
+	return followList[Legalhold](ctx, c, custodian.Links.LegalHolds.Href, "legal_holds")
+}
+
+// GetCustodianMatters returns the matters a custodian is part of.
+func (c *Client) GetCustodianMatters(custodian Custodian) (Matters, error) {
+	return c.GetCustodianMattersContext(context.Background(), custodian)
+}
+
+// GetCustodianMattersContext is like GetCustodianMatters but carries ctx.
+func (c *Client) GetCustodianMattersContext(ctx context.Context, custodian Custodian) (Matters, error) {
+	return followList[Matter](ctx, c, custodian.Links.Matters.Href, "matters")
+}
+
+// GetGroupFolders returns the folders a group has access to.
+func (c *Client) GetGroupFolders(group Group) (Folders, error) {
+	return c.GetGroupFoldersContext(context.Background(), group)
+}
+
+// GetGroupFoldersContext is like GetGroupFolders but carries ctx.
+func (c *Client) GetGroupFoldersContext(ctx context.Context, group Group) (Folders, error) {
+	return followList[Folder](ctx, c, group.Links.Folders.Href, "folders")
+}
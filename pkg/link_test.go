@@ -0,0 +1,147 @@
+package otlh_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestFollow(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	folder := srv.AddFolder(otlh.Folder{Name: "Acme"})
+	matter := srv.AddMatter(otlh.Matter{Name: "Contract"}, folder.ID)
+
+	// more custodians than fit on two pages of the server
+	var ids []int
+	for i := range 2*otlhtest.DEFAULT_PAGE_SIZE + 10 {
+		custodian := srv.AddCustodian(otlh.Custodian{Name: fmt.Sprintf("Custodian %02d", i), Email: fmt.Sprintf("c%02d@acme.com", i)})
+		ids = append(ids, custodian.ID)
+	}
+	legalhold := srv.AddLegalhold(otlh.Legalhold{Name: "Acme", MatterID: matter.ID}, ids...)
+
+	client := srv.Client()
+
+	tests := []struct {
+		name         string
+		follow       func() ([]int, error)
+		want         []int
+		wantRequests int
+		wantErr      error
+	}{
+		{
+			name: "single object",
+			follow: func() ([]int, error) {
+				m, err := client.GetLegalholdMatter(legalhold)
+				return []int{m.ID}, err
+			},
+			want:         []int{matter.ID},
+			wantRequests: 1,
+		},
+		{
+			name: "absolute URL",
+			follow: func() ([]int, error) {
+				var f otlh.Folder
+				err := client.Follow(srv.URL+matter.Links.Folder.Href, &f)
+				return []int{f.ID}, err
+			},
+			want:         []int{folder.ID},
+			wantRequests: 1,
+		},
+		{
+			name: "paginated target",
+			follow: func() ([]int, error) {
+				custodians, err := client.GetLegalholdCustodians(legalhold)
+				found := make([]int, len(custodians))
+				for i, custodian := range custodians {
+					found[i] = custodian.ID
+				}
+				return found, err
+			},
+			want:         ids,
+			wantRequests: 3,
+		},
+		{
+			name: "list on one page",
+			follow: func() ([]int, error) {
+				matters, err := client.GetFolderMatters(folder)
+				found := make([]int, len(matters))
+				for i, m := range matters {
+					found[i] = m.ID
+				}
+				return found, err
+			},
+			want:         []int{matter.ID},
+			wantRequests: 1,
+		},
+		{
+			name: "empty href",
+			follow: func() ([]int, error) {
+				f, err := client.GetMatterFolder(otlh.Matter{Name: "Unlinked"})
+				return []int{f.ID}, err
+			},
+			wantErr: otlh.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := len(srv.Requests())
+			got, err := tt.follow()
+
+			if n := len(srv.Requests()) - sent; n != tt.wantRequests {
+				t.Fatalf("got %d requests, want %d", n, tt.wantRequests)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got ids %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFollowMergesEmbedded(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	var ids []int
+	for i := range otlhtest.DEFAULT_PAGE_SIZE + 5 {
+		ids = append(ids, srv.AddCustodian(otlh.Custodian{Name: fmt.Sprintf("Custodian %02d", i)}).ID)
+	}
+	legalhold := srv.AddLegalhold(otlh.Legalhold{Name: "Acme"}, ids...)
+
+	var merged struct {
+		otlh.DefaultEntityListInfo
+		Embedded map[string][]otlh.Custodian `json:"_embedded"`
+	}
+	if err := srv.Client().Follow(legalhold.Links.Custodians.Href, &merged); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(merged.Embedded["custodians"]); n != len(ids) {
+		t.Fatalf("got %d custodians under _embedded, want %d", n, len(ids))
+	}
+	if merged.Page.HasMore || merged.Page.TotalCount != len(ids) {
+		t.Fatalf("got has-more %v and total-count %d, want false and %d", merged.Page.HasMore, merged.Page.TotalCount, len(ids))
+	}
+
+	for _, r := range srv.Requests()[1:] {
+		if !strings.HasSuffix(r.Path, "/custodians") || r.Query.Get("page_number") == "" {
+			t.Fatalf("got request %s?%s for a later page, want page_number set", r.Path, r.Query.Encode())
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package otlh
 
-import "fmt"
+import (
+	"fmt"
+	"path"
+	"strconv"
+)
 
 type Matter struct {
 	ID                   int    `json:"id,omitempty"`
@@ -46,6 +50,15 @@ type Matter struct {
 
 type Matters []Matter
 
+// FolderID returns the id of the folder holding the matter, taken from its folder link, or 0.
+func (m Matter) FolderID() int {
+	if m.Links.Folder.Href == "" {
+		return 0
+	}
+	id, _ := strconv.Atoi(path.Base(m.Links.Folder.Href))
+	return id
+}
+
 type MatterRequest struct {
 	id int
 	Request
@@ -0,0 +1,217 @@
+package otlh
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/html"
+)
+
+/*
+Notice is a hold notice, advisory notice or release notice. Body is HTML, see
+PlainText for a text version.
+*/
+type Notice struct {
+	ID          int                `json:"id,omitempty"`
+	Subject     string             `json:"subject"`
+	Title       string             `json:"title"`
+	Body        string             `json:"body"`
+	Attachments []NoticeAttachment `json:"attachments,omitempty"`
+}
+
+// NoticeAttachment is a file attached to a notice, see DownloadNoticeAttachment.
+type NoticeAttachment struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	Links       struct {
+		Download struct {
+			Href string `json:"href,omitempty"`
+		} `json:"download,omitempty"`
+	} `json:"_links,omitempty"`
+}
+
+/*
+AdvisoryCopy is the advisory notice of a silent hold as it was sent to one
+recipient, e.g. the custodian's manager.
+*/
+type AdvisoryCopy struct {
+	Notice
+	SentAt    string `json:"sent_at,omitempty"`
+	Recipient struct {
+		Name  string `json:"name,omitempty"`
+		Email string `json:"email,omitempty"`
+	} `json:"recipient,omitempty"`
+}
+
+type AdvisoryCopies []AdvisoryCopy
+
+// GetLegalholdNotice returns the hold notice of a legal hold.
+func (c *Client) GetLegalholdNotice(legalhold Legalhold) (Notice, error) {
+	return c.GetLegalholdNoticeContext(context.Background(), legalhold)
+}
+
+// GetLegalholdNoticeContext is like GetLegalholdNotice but carries ctx.
+func (c *Client) GetLegalholdNoticeContext(ctx context.Context, legalhold Legalhold) (Notice, error) {
+	var notice Notice
+	err := c.FollowContext(ctx, legalhold.Links.HoldNotice.Href, &notice)
+	return notice, err
+}
+
+// GetSilentholdAdvisoryNotice returns the advisory notice of a silent hold.
+func (c *Client) GetSilentholdAdvisoryNotice(silenthold Silenthold) (Notice, error) {
+	return c.GetSilentholdAdvisoryNoticeContext(context.Background(), silenthold)
+}
+
+// GetSilentholdAdvisoryNoticeContext is like GetSilentholdAdvisoryNotice but carries ctx.
+func (c *Client) GetSilentholdAdvisoryNoticeContext(ctx context.Context, silenthold Silenthold) (Notice, error) {
+	var notice Notice
+	err := c.FollowContext(ctx, silenthold.Links.AdvisoryNotice.Href, &notice)
+	return notice, err
+}
+
+// GetSilentholdReleaseNotice returns the release notice of a silent hold.
+func (c *Client) GetSilentholdReleaseNotice(silenthold Silenthold) (Notice, error) {
+	return c.GetSilentholdReleaseNoticeContext(context.Background(), silenthold)
+}
+
+// GetSilentholdReleaseNoticeContext is like GetSilentholdReleaseNotice but carries ctx.
+func (c *Client) GetSilentholdReleaseNoticeContext(ctx context.Context, silenthold Silenthold) (Notice, error) {
+	var notice Notice
+	err := c.FollowContext(ctx, silenthold.Links.ReleaseNotice.Href, &notice)
+	return notice, err
+}
+
+// GetSilentholdAdvisoryCopies returns every copy of the advisory notice sent for a silent hold.
+func (c *Client) GetSilentholdAdvisoryCopies(silenthold Silenthold) (AdvisoryCopies, error) {
+	return c.GetSilentholdAdvisoryCopiesContext(context.Background(), silenthold)
+}
+
+// GetSilentholdAdvisoryCopiesContext is like GetSilentholdAdvisoryCopies but carries ctx.
+func (c *Client) GetSilentholdAdvisoryCopiesContext(ctx context.Context, silenthold Silenthold) (AdvisoryCopies, error) {
+	return followList[AdvisoryCopy](ctx, c, silenthold.Links.AdvisoryCopies.Href, "advisory_copies")
+}
+
+/*
+DownloadNoticeAttachment saves an attachment into dir as <id>_<name> and
+returns the path written. The id keeps attachments of the same name, e.g. a
+policy.pdf attached to several notices, from overwriting each other. Only the
+base of the name is used, so a name can not point outside dir.
+*/
+func (c *Client) DownloadNoticeAttachment(attachment NoticeAttachment, dir string) (string, error) {
+	return c.DownloadNoticeAttachmentContext(context.Background(), attachment, dir)
+}
+
+// DownloadNoticeAttachmentContext is like DownloadNoticeAttachment but carries ctx.
+func (c *Client) DownloadNoticeAttachmentContext(ctx context.Context, attachment NoticeAttachment, dir string) (string, error) {
+	path, err := linkPath(attachment.Links.Download.Href)
+	if err != nil {
+		return "", fmt.Errorf("attachment %s: %w", attachment.Name, err)
+	}
+
+	content, err := c.SendContext(ctx, NewRawRequest(GET, c.tenant, path))
+	if err != nil {
+		return "", err
+	}
+
+	name := filepath.Base(filepath.FromSlash(attachment.Name))
+	if name == "." || name == string(filepath.Separator) {
+		name = "attachment"
+	}
+	name = fmt.Sprintf("%d_%s", attachment.ID, name)
+
+	file := filepath.Join(dir, name)
+	if err = os.WriteFile(file, content, 0644); err != nil {
+		return "", err
+	}
+
+	log.Debug().Msgf("saved attachment %s to %s", attachment.Name, file)
+	return file, nil
+}
+
+var (
+	spacesPattern     = regexp.MustCompile(`\s+`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+/*
+PlainText returns the body as text: tags are dropped, block elements and <br>
+end a line, list items start with "- " and links are followed by their URL.
+A body without any tag is returned as is.
+*/
+func (n Notice) PlainText() string {
+	// bodies imported from a spreadsheet may already be plain text
+	if !strings.Contains(n.Body, "<") {
+		return strings.TrimSpace(n.Body) + "\n"
+	}
+
+	var sb strings.Builder
+	var href string
+	var skip int
+
+	z := html.NewTokenizer(strings.NewReader(n.Body))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		tok := z.Token()
+		switch tt {
+		case html.TextToken:
+			if skip == 0 {
+				sb.WriteString(spacesPattern.ReplaceAllString(tok.Data, " "))
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			switch tok.Data {
+			case "script", "style", "head":
+				skip++
+			case "br":
+				sb.WriteString("\n")
+			case "li":
+				sb.WriteString("\n- ")
+			case "p", "div", "h1", "h2", "h3", "h4", "h5", "h6", "tr", "ul", "ol", "table", "blockquote":
+				sb.WriteString("\n\n")
+			case "a":
+				for _, attr := range tok.Attr {
+					if attr.Key == "href" {
+						href = attr.Val
+					}
+				}
+			}
+
+		case html.EndTagToken:
+			switch tok.Data {
+			case "script", "style", "head":
+				if skip > 0 {
+					skip--
+				}
+			case "p", "div", "h1", "h2", "h3", "h4", "h5", "h6", "tr", "ul", "ol", "table", "blockquote":
+				sb.WriteString("\n\n")
+			case "td", "th":
+				sb.WriteString(" ")
+			case "a":
+				if href != "" && !strings.HasPrefix(href, "#") {
+					sb.WriteString(" <" + href + ">")
+				}
+				href = ""
+			}
+		}
+	}
+
+	// trim the spaces left around line breaks and collapse runs of blank lines
+	lines := strings.Split(sb.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	text := blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	return strings.TrimSpace(text) + "\n"
+}
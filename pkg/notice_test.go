@@ -0,0 +1,133 @@
+package otlh_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestNoticePlainText(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "plain text",
+			body: "  Please keep all documents.\n\nThank you.  ",
+			want: "Please keep all documents.\n\nThank you.\n",
+		},
+		{
+			name: "paragraphs and line breaks",
+			body: "<p>Dear   custodian,</p><p>keep<br>everything.</p>",
+			want: "Dear custodian,\n\nkeep\neverything.\n",
+		},
+		{
+			name: "list",
+			body: "<p>Keep:</p><ul><li>email</li><li>chat  logs</li></ul><p>Thanks</p>",
+			want: "Keep:\n\n- email\n- chat logs\n\nThanks\n",
+		},
+		{
+			name: "links",
+			body: `<p>See <a href="https://acme.com/faq">the FAQ</a> or <a href="#top">top</a>.</p>`,
+			want: "See the FAQ <https://acme.com/faq> or top.\n",
+		},
+		{
+			name: "head, style and script dropped",
+			body: "<html><head><title>Notice</title><style>p {color: red}</style></head><body><script>alert(1)</script><h1>Hold</h1><div>Body</div></body></html>",
+			want: "Hold\n\nBody\n",
+		},
+		{
+			name: "table cells",
+			body: "<table><tr><th>Matter</th><th>Due</th></tr><tr><td>Acme</td><td>2026-11-01</td></tr></table>",
+			want: "Matter Due\n\nAcme 2026-11-01\n",
+		},
+		{
+			name: "entities",
+			body: "<p>R&amp;D &lt;legal&gt; team&nbsp;only</p>",
+			want: "R&D <legal> team only\n",
+		},
+		{
+			name: "empty",
+			body: "",
+			want: "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (otlh.Notice{Body: tt.body}).PlainText(); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetLegalholdNoticePlainText(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	legalhold := srv.AddLegalhold(otlh.Legalhold{Name: "Acme"})
+	srv.SetNotice(legalhold.Links.HoldNotice.Href, otlh.Notice{Subject: "Legal hold", Body: "<p>Keep <b>all</b> records.</p>"})
+
+	notice, err := srv.Client().GetLegalholdNotice(legalhold)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notice.Subject != "Legal hold" {
+		t.Fatalf("got subject %q, want %q", notice.Subject, "Legal hold")
+	}
+	if got, want := notice.PlainText(), "Keep all records.\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestDownloadNoticeAttachment(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	// the same name attached to two notices must not overwrite each other
+	first := srv.AddAttachment("policy.pdf", []byte("first policy"))
+	second := srv.AddAttachment("policy.pdf", []byte("second policy"))
+	outside := srv.AddAttachment("../../outside.txt", []byte("outside"))
+	unnamed := srv.AddAttachment("/", []byte("unnamed"))
+
+	client := srv.Client()
+	dir := t.TempDir()
+
+	tests := []struct {
+		attachment otlh.NoticeAttachment
+		wantName   string
+		want       string
+	}{
+		{first, fmt.Sprintf("%d_policy.pdf", first.ID), "first policy"},
+		{second, fmt.Sprintf("%d_policy.pdf", second.ID), "second policy"},
+		{outside, fmt.Sprintf("%d_outside.txt", outside.ID), "outside"},
+		{unnamed, fmt.Sprintf("%d_attachment", unnamed.ID), "unnamed"},
+	}
+
+	for _, tt := range tests {
+		path, err := client.DownloadNoticeAttachment(tt.attachment, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := filepath.Join(dir, tt.wantName); path != want {
+			t.Fatalf("got path %s, want %s", path, want)
+		}
+	}
+
+	// every file is checked once all are written, a later one could have overwritten it
+	for _, tt := range tests {
+		content, err := os.ReadFile(filepath.Join(dir, tt.wantName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != tt.want {
+			t.Fatalf("got %q in %s, want %q", content, tt.wantName, tt.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package otlhtest
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	SHEET_NAME_HOLD_DETAILS       = "hold_details"
+	SHEET_NAME_CUSTODIANS_DETAILS = "custodian_details"
+)
+
+/*
+HoldPackage is the decoded content of a legal_hold_details.zip or
+silent_hold_details.zip package uploaded to one of the import endpoints.
+
+Details maps the header of the hold_details sheet to its value, e.g.
+Details["Hold Name"]. Custodians holds one such map per row of the
+custodian_details sheet. Every other file in the zip is an attachment.
+*/
+type HoldPackage struct {
+	Workbook    string
+	Details     map[string]string
+	Custodians  []map[string]string
+	Attachments map[string][]byte
+}
+
+// Detail returns the hold_details value for header, ignoring case.
+func (p HoldPackage) Detail(header string) string {
+	for k, v := range p.Details {
+		if strings.EqualFold(k, header) {
+			return v
+		}
+	}
+	return ""
+}
+
+// CustodianEmails returns the Email column of the custodian_details sheet.
+func (p HoldPackage) CustodianEmails() []string {
+	var emails []string
+	for _, row := range p.Custodians {
+		emails = append(emails, row["Email"])
+	}
+	return emails
+}
+
+/*
+ParseHoldPackage decodes a hold import package. workbook is the name of the
+excel file expected inside the zip, e.g. legal_hold_details.xlsx.
+*/
+func ParseHoldPackage(data []byte, workbook string) (HoldPackage, error) {
+	pkg := HoldPackage{
+		Workbook:    workbook,
+		Details:     map[string]string{},
+		Attachments: map[string][]byte{},
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return pkg, err
+	}
+
+	var found bool
+	for _, zf := range zr.File {
+		content, err := readZipFile(zf)
+		if err != nil {
+			return pkg, err
+		}
+
+		if path.Base(zf.Name) != workbook {
+			pkg.Attachments[path.Base(zf.Name)] = content
+			continue
+		}
+
+		found = true
+		if err = pkg.parseWorkbook(content); err != nil {
+			return pkg, err
+		}
+	}
+
+	if !found {
+		return pkg, fmt.Errorf("%s not found in package", workbook)
+	}
+
+	return pkg, nil
+}
+
+func readZipFile(zf *zip.File) ([]byte, error) {
+	rc, err := zf.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+func (p *HoldPackage) parseWorkbook(content []byte) error {
+	f, err := excelize.OpenReader(bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	details, err := sheetRecords(f, SHEET_NAME_HOLD_DETAILS)
+	if err != nil {
+		return err
+	}
+	if len(details) > 0 {
+		p.Details = details[0]
+	}
+
+	p.Custodians, err = sheetRecords(f, SHEET_NAME_CUSTODIANS_DETAILS)
+	return err
+}
+
+// sheetRecords maps every row after the header row to a header -> value map.
+func sheetRecords(f *excelize.File, sheet string) ([]map[string]string, error) {
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	header := rows[0]
+	var records []map[string]string
+	for _, row := range rows[1:] {
+		record := make(map[string]string, len(header))
+		for i, col := range header {
+			if i < len(row) {
+				record[col] = row[i]
+			} else {
+				record[col] = ""
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
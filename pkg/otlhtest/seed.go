@@ -0,0 +1,298 @@
+package otlhtest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+)
+
+/*
+The Add* helpers seed the server state. Each one assigns a new ID when the
+entity has none, fills in the _links the service returns and returns the
+stored entity.
+*/
+
+func (s *Server) AddCustodian(c otlh.Custodian) otlh.Custodian {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addCustodian(c)
+}
+
+func (s *Server) addCustodian(c otlh.Custodian) otlh.Custodian {
+	if c.ID == 0 {
+		c.ID = s.newID()
+	}
+	c.Links.Self.Href = s.href("/custodians/%d", c.ID)
+	c.Links.LegalHolds.Href = s.href("/custodians/%d/legal_holds", c.ID)
+	c.Links.Matters.Href = s.href("/custodians/%d/matters", c.ID)
+
+	s.custodians = append(s.custodians, c)
+	return c
+}
+
+func (s *Server) AddCustodianGroup(g otlh.CustodianGroup, custodianIDs ...int) otlh.CustodianGroup {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if g.ID == 0 {
+		g.ID = s.newID()
+	}
+	g.CustodiansCount = len(custodianIDs)
+	g.Links.Self.Href = s.href("/custodian_groups/%d", g.ID)
+	g.Links.Custodians.Href = s.href("/custodian_groups/%d/custodians", g.ID)
+
+	s.custodianGroups = append(s.custodianGroups, g)
+	s.members[fmt.Sprintf("custodian_groups/%d", g.ID)] = slices.Clone(custodianIDs)
+	return g
+}
+
+func (s *Server) AddFolder(f otlh.Folder) otlh.Folder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addFolder(f)
+}
+
+func (s *Server) addFolder(f otlh.Folder) otlh.Folder {
+	if f.ID == 0 {
+		f.ID = s.newID()
+	}
+	f.Links.Self.Href = s.href("/folders/%d", f.ID)
+	f.Links.Matters.Href = s.href("/folders/%d/matters", f.ID)
+	f.Links.Groups.Href = s.href("/folders/%d/groups", f.ID)
+	f.Links.Stats.Href = s.href("/folders/%d/stats", f.ID)
+
+	s.folders = append(s.folders, f)
+	return f
+}
+
+// AddGroup adds a group owning the folders with folderIDs.
+func (s *Server) AddGroup(g otlh.Group, folderIDs ...int) otlh.Group {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if g.ID == 0 {
+		g.ID = s.newID()
+	}
+	g.Links.Self.Href = s.href("/groups/%d", g.ID)
+	g.Links.Folders.Href = s.href("/groups/%d/folders", g.ID)
+
+	s.groups = append(s.groups, g)
+	s.groupFolders[g.ID] = slices.Clone(folderIDs)
+	return g
+}
+
+// AddMatter adds a matter to the folder with folderID, one added with folderID 0 has no folder link.
+func (s *Server) AddMatter(m otlh.Matter, folderID int) otlh.Matter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addMatter(m, folderID)
+}
+
+func (s *Server) addMatter(m otlh.Matter, folderID int) otlh.Matter {
+	if m.ID == 0 {
+		m.ID = s.newID()
+	}
+	m.Links.Self.Href = s.href("/matters/%d", m.ID)
+	m.Links.LegalHolds.Href = s.href("/matters/%d/legal_holds", m.ID)
+	m.Links.Custodians.Href = s.href("/matters/%d/custodians", m.ID)
+	m.Links.Stats.Href = s.href("/matters/%d/stats", m.ID)
+	if folderID > 0 {
+		m.Links.Folder.Href = s.href("/folders/%d", folderID)
+	}
+
+	s.matters = append(s.matters, m)
+	s.matterFolder[m.ID] = folderID
+	return m
+}
+
+// AddLegalhold adds a legal hold with the custodians in custodianIDs.
+func (s *Server) AddLegalhold(h otlh.Legalhold, custodianIDs ...int) otlh.Legalhold {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h = s.addLegalhold(h)
+	s.members[fmt.Sprintf("legal_holds/%d", h.ID)] = slices.Clone(custodianIDs)
+	return h
+}
+
+func (s *Server) addLegalhold(h otlh.Legalhold) otlh.Legalhold {
+	if h.ID == 0 {
+		h.ID = s.newID()
+	}
+	h.Links.Self.Href = s.href("/legal_holds/%d", h.ID)
+	h.Links.Custodians.Href = s.href("/legal_holds/%d/custodians", h.ID)
+	h.Links.HoldNotice.Href = s.href("/legal_holds/%d/hold_notice", h.ID)
+	h.Links.History.Href = s.href("/legal_holds/%d/history", h.ID)
+	h.Links.Stats.Href = s.href("/legal_holds/%d/stats", h.ID)
+	h.Links.Matter.Href = s.href("/matters/%d", h.MatterID)
+
+	s.legalholds = append(s.legalholds, h)
+	return h
+}
+
+// AddSilenthold adds a silent hold with the custodians in custodianIDs.
+func (s *Server) AddSilenthold(h otlh.Silenthold, custodianIDs ...int) otlh.Silenthold {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h = s.addSilenthold(h)
+	s.members[fmt.Sprintf("silent_holds/%d", h.ID)] = slices.Clone(custodianIDs)
+	return h
+}
+
+func (s *Server) addSilenthold(h otlh.Silenthold) otlh.Silenthold {
+	if h.ID == 0 {
+		h.ID = s.newID()
+	}
+	h.Links.Self.Href = s.href("/silent_holds/%d", h.ID)
+	h.Links.Custodians.Href = s.href("/silent_holds/%d/custodians", h.ID)
+	h.Links.AdvisoryNotice.Href = s.href("/silent_holds/%d/advisory_notice", h.ID)
+	h.Links.ReleaseNotice.Href = s.href("/silent_holds/%d/release_notice", h.ID)
+	h.Links.AdvisoryCopies.Href = s.href("/silent_holds/%d/advisory_copies", h.ID)
+	h.Links.History.Href = s.href("/silent_holds/%d/history", h.ID)
+	h.Links.Stats.Href = s.href("/silent_holds/%d/stats", h.ID)
+	h.Links.Matter.Href = s.href("/matters/%d", h.MatterID)
+
+	s.silentholds = append(s.silentholds, h)
+	return h
+}
+
+/*
+SetLegalholdStats sets the notice counts served for a legal hold, instead of
+those derived from its custodians.
+*/
+func (s *Server) SetLegalholdStats(legalholdID int, counts otlh.NoticeCounts) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.legalholdStats[legalholdID] = counts
+}
+
+// AddLegalholdHistory appends events to the history of a legal hold.
+func (s *Server) AddLegalholdHistory(legalholdID int, events ...otlh.HistoryEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addHistory(fmt.Sprintf("legal_holds/%d", legalholdID), events...)
+}
+
+// AddSilentholdHistory appends events to the history of a silent hold.
+func (s *Server) AddSilentholdHistory(silentholdID int, events ...otlh.HistoryEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addHistory(fmt.Sprintf("silent_holds/%d", silentholdID), events...)
+}
+
+func (s *Server) addHistory(key string, events ...otlh.HistoryEvent) {
+	for _, e := range events {
+		if e.ID == 0 {
+			e.ID = s.newID()
+		}
+		s.history[key] = append(s.history[key], e)
+	}
+}
+
+/*
+SetNotice sets the notice served at href, the HoldNotice link of a legal hold
+or the AdvisoryNotice or ReleaseNotice link of a silent hold. Imported holds
+get the notice of their package.
+*/
+func (s *Server) SetNotice(href string, notice otlh.Notice) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.setNotice(href, notice)
+}
+
+func (s *Server) setNotice(href string, notice otlh.Notice) {
+	if notice.ID == 0 {
+		notice.ID = s.newID()
+	}
+	s.noticeTexts[href] = notice
+}
+
+// AddAttachment stores a file to attach to a notice, with its download link.
+func (s *Server) AddAttachment(name string, content []byte) otlh.NoticeAttachment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addAttachment(name, content)
+}
+
+func (s *Server) addAttachment(name string, content []byte) otlh.NoticeAttachment {
+	a := otlh.NoticeAttachment{
+		ID:          s.newID(),
+		Name:        name,
+		ContentType: http.DetectContentType(content),
+		Size:        int64(len(content)),
+	}
+	a.Links.Download.Href = s.href("/attachments/%d", a.ID)
+
+	s.attachments[a.ID] = slices.Clone(content)
+	return a
+}
+
+// AddAdvisoryCopies appends sent copies of the advisory notice of a silent hold.
+func (s *Server) AddAdvisoryCopies(silentholdID int, copies ...otlh.AdvisoryCopy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range copies {
+		if c.ID == 0 {
+			c.ID = s.newID()
+		}
+		s.advisoryCopies[silentholdID] = append(s.advisoryCopies[silentholdID], c)
+	}
+}
+
+func (s *Server) AddQuestionnaire(q otlh.Questionnaire) otlh.Questionnaire {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if q.ID == 0 {
+		q.ID = s.newID()
+	}
+
+	s.questionnaires = append(s.questionnaires, q)
+	return q
+}
+
+// Custodians returns the custodians currently stored.
+func (s *Server) Custodians() []otlh.Custodian {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.custodians)
+}
+
+// Folders returns the folders currently stored.
+func (s *Server) Folders() []otlh.Folder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.folders)
+}
+
+// Matters returns the matters currently stored.
+func (s *Server) Matters() []otlh.Matter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.matters)
+}
+
+// Legalholds returns the legal holds currently stored.
+func (s *Server) Legalholds() []otlh.Legalhold {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.legalholds)
+}
+
+// Silentholds returns the silent holds currently stored.
+func (s *Server) Silentholds() []otlh.Silenthold {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.silentholds)
+}
+
+// MatterFolderID returns the id of the folder a matter was created in.
+func (s *Server) MatterFolderID(matterID int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.matterFolder[matterID]
+}
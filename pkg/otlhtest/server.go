@@ -0,0 +1,1254 @@
+/*
+Package otlhtest provides an in-process fake of the OpenText Legal Hold API
+for tests of code built on the otlh package.
+
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	folder := srv.AddFolder(otlh.Folder{Name: "Acme"})
+	client := srv.Client()
+
+The server keeps its entities in memory, paginates lists the same way the
+service does (HAL style _embedded and page objects) and decodes uploaded hold
+packages so tests can assert on what was sent. Faults can be injected to
+exercise retries and error handling.
+*/
+package otlhtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+)
+
+const (
+	AUTH_TOKEN        = "otlhtest-token"
+	DEFAULT_PAGE_SIZE = 25
+)
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+/*
+Fault describes a failure the server returns instead of handling a request.
+
+A fault applies to requests whose method equals Method and whose path
+contains Path, empty values match every request. Times limits how many
+requests are affected, 0 means every matching request. With CloseConnection
+set the connection is dropped without a response to simulate a transport
+failure, otherwise StatusCode, Header and Body are written after Delay. A
+fault without StatusCode only delays the request, which is then handled as
+usual, e.g. to simulate a slow service.
+*/
+type Fault struct {
+	Method          string
+	Path            string
+	StatusCode      int
+	Header          http.Header
+	Body            string
+	Delay           time.Duration
+	CloseConnection bool
+	Times           int
+
+	hits int
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	if f.Times > 0 && f.hits >= f.Times {
+		return false
+	}
+	if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+		return false
+	}
+	return strings.Contains(r.URL.Path, f.Path)
+}
+
+// Server is a fake OpenText Legal Hold API.
+type Server struct {
+	*httptest.Server
+
+	tenant string
+
+	mu     sync.Mutex
+	nextID int
+
+	custodians      []otlh.Custodian
+	custodianGroups []otlh.CustodianGroup
+	folders         []otlh.Folder
+	groups          []otlh.Group
+	matters         []otlh.Matter
+	legalholds      []otlh.Legalhold
+	silentholds     []otlh.Silenthold
+	questionnaires  []otlh.Questionnaire
+
+	// members maps "<collection>/<id>" to the ids of the custodians on it,
+	// e.g. "legal_holds/10".
+	members map[string][]int
+
+	matterFolder map[int]int
+	groupFolders map[int][]int
+
+	// legalholdStats holds the counts set with SetLegalholdStats, by hold id
+	legalholdStats map[int]otlh.NoticeCounts
+
+	// history maps "<collection>/<id>" to the events of a hold
+	history map[string][]otlh.HistoryEvent
+
+	// noticeTexts maps the path of a notice link to the notice
+	noticeTexts    map[string]otlh.Notice
+	advisoryCopies map[int][]otlh.AdvisoryCopy
+	attachments    map[int][]byte
+
+	legalholdImports  []HoldPackage
+	silentholdImports []HoldPackage
+	custodianImports  [][]otlh.CustodianInputData
+	notices           []json.RawMessage
+
+	faults   []*Fault
+	requests []Request
+}
+
+// NewServer starts a TLS server serving tenant. Close it when done.
+func NewServer(tenant string) *Server {
+	s := &Server{
+		tenant:         tenant,
+		nextID:         1000,
+		members:        map[string][]int{},
+		matterFolder:   map[int]int{},
+		groupFolders:   map[int][]int{},
+		legalholdStats: map[int]otlh.NoticeCounts{},
+		history:        map[string][]otlh.HistoryEvent{},
+		noticeTexts:    map[string]otlh.Notice{},
+		advisoryCopies: map[int][]otlh.AdvisoryCopy{},
+		attachments:    map[int][]byte{},
+	}
+
+	s.Server = httptest.NewTLSServer(s.routes())
+	return s
+}
+
+// ClientBuilder returns a builder configured to talk to the server.
+func (s *Server) ClientBuilder() *otlh.ClientBuilder {
+	u, _ := url.Parse(s.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	retryPolicy := otlh.DefaultRetryPolicy()
+	retryPolicy.InitialBackoff = time.Millisecond
+	retryPolicy.MaxBackoff = 10 * time.Millisecond
+
+	return otlh.NewClientBuilder().
+		WithDomain(u.Hostname()).
+		WithPort(port).
+		WithTenant(s.tenant).
+		WithAuthToken(AUTH_TOKEN).
+		WithRetryPolicy(retryPolicy).
+		SkipVerify()
+}
+
+// Client returns a client talking to the server.
+func (s *Server) Client() *otlh.Client {
+	return s.ClientBuilder().Build()
+}
+
+func (s *Server) base() string {
+	return fmt.Sprintf("/t/%s/api/%s", s.tenant, otlh.APIVERSION)
+}
+
+func (s *Server) href(format string, a ...any) string {
+	return s.base() + fmt.Sprintf(format, a...)
+}
+
+func (s *Server) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+// InjectFault adds a fault, faults are evaluated in the order they were added.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns every request received so far, including faulted ones.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// LegalholdImports returns the packages uploaded to legal_holds/import.
+func (s *Server) LegalholdImports() []HoldPackage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.legalholdImports)
+}
+
+// SilentholdImports returns the packages uploaded to silent_holds/import.
+func (s *Server) SilentholdImports() []HoldPackage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.silentholdImports)
+}
+
+// CustodianImports returns the batches posted to custodians/import.
+func (s *Server) CustodianImports() [][]otlh.CustodianInputData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.custodianImports)
+}
+
+// Notices returns the bodies posted to legal_holds/send_notice.
+func (s *Server) Notices() []json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.notices)
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	p := "/t/{tenant}/api/" + otlh.APIVERSION
+
+	mux.HandleFunc("GET "+p+"/custodians", s.listCustodians)
+	mux.HandleFunc("GET "+p+"/custodians/{id}", s.getCustodian)
+	mux.HandleFunc("GET "+p+"/custodians/{id}/custodian_groups", s.listCustodianGroupsOfCustodian)
+	mux.HandleFunc("POST "+p+"/custodians/import", s.importCustodians)
+	mux.HandleFunc("DELETE "+p+"/custodians/{id}", s.deleteCustodian)
+	mux.HandleFunc("GET "+p+"/custodians/{id}/legal_holds", listWhere(s, "legal_holds", &s.legalholds, legalholdName, s.legalholdHasCustodian))
+	mux.HandleFunc("GET "+p+"/custodians/{id}/matters", listWhere(s, "matters", &s.matters, matterName, s.matterHasCustodian))
+	mux.HandleFunc("GET "+p+"/custodian_groups", s.listCustodianGroups)
+	mux.HandleFunc("GET "+p+"/custodian_groups/{id}", s.getCustodianGroup)
+	mux.HandleFunc("GET "+p+"/custodian_groups/{id}/custodians", s.listMembers("custodian_groups"))
+	mux.HandleFunc("DELETE "+p+"/custodian_groups/{id}", s.deleteCustodianGroup)
+	mux.HandleFunc("GET "+p+"/folders", s.listFolders)
+	mux.HandleFunc("POST "+p+"/folders", s.createFolder)
+	mux.HandleFunc("GET "+p+"/folders/{id}", s.getFolder)
+	mux.HandleFunc("DELETE "+p+"/folders/{id}", s.deleteFolder)
+	mux.HandleFunc("GET "+p+"/folders/{id}/matters", listWhere(s, "matters", &s.matters, matterName, s.matterInFolder))
+	mux.HandleFunc("GET "+p+"/folders/{id}/groups", listWhere(s, "groups", &s.groups, groupName, s.groupHasFolder))
+	mux.HandleFunc("GET "+p+"/groups", s.listGroups)
+	mux.HandleFunc("GET "+p+"/groups/{id}", s.getGroup)
+	mux.HandleFunc("GET "+p+"/groups/{id}/folders", s.listFoldersOfGroup)
+	mux.HandleFunc("GET "+p+"/matters", s.listMatters)
+	mux.HandleFunc("POST "+p+"/matters", s.createMatter)
+	mux.HandleFunc("GET "+p+"/matters/{id}", s.getMatter)
+	mux.HandleFunc("PATCH "+p+"/matters/{id}", s.updateMatter)
+	mux.HandleFunc("DELETE "+p+"/matters/{id}", s.deleteMatter)
+	mux.HandleFunc("GET "+p+"/matters/{id}/custodians", s.listMatterCustodians)
+	mux.HandleFunc("GET "+p+"/matters/{id}/stats", s.getMatterStats)
+	mux.HandleFunc("GET "+p+"/matters/{id}/legal_holds", listWhere(s, "legal_holds", &s.legalholds, legalholdName, legalholdOfMatter))
+	mux.HandleFunc("POST "+p+"/matters/{id}/custodians/import", s.importCustodians)
+	mux.HandleFunc("GET "+p+"/legal_holds", s.listLegalholds)
+	mux.HandleFunc("GET "+p+"/legal_holds/{id}", s.getLegalhold)
+	mux.HandleFunc("GET "+p+"/legal_holds/{id}/custodians", s.listMembers("legal_holds"))
+	mux.HandleFunc("GET "+p+"/legal_holds/{id}/stats", s.getLegalholdStats)
+	mux.HandleFunc("GET "+p+"/legal_holds/{id}/history", s.listHistory("legal_holds"))
+	mux.HandleFunc("GET "+p+"/legal_holds/{id}/hold_notice", s.getNotice)
+	mux.HandleFunc("POST "+p+"/legal_holds/import", s.importLegalhold)
+	mux.HandleFunc("POST "+p+"/legal_holds/send_notice", s.sendNotice)
+	mux.HandleFunc("POST "+p+"/legal_holds/send_acknowledgement_reminders", s.sendReminders(otlh.ACKNOWLEDGEMENT_REMINDER))
+	mux.HandleFunc("POST "+p+"/legal_holds/send_hold_reminders", s.sendReminders(otlh.HOLD_REMINDER))
+	mux.HandleFunc("POST "+p+"/legal_holds/release_custodians", s.releaseCustodians("legal_holds"))
+	mux.HandleFunc("GET "+p+"/silent_holds", s.listSilentholds)
+	mux.HandleFunc("GET "+p+"/silent_holds/{id}", s.getSilenthold)
+	mux.HandleFunc("GET "+p+"/silent_holds/{id}/custodians", s.listMembers("silent_holds"))
+	mux.HandleFunc("GET "+p+"/silent_holds/{id}/history", s.listHistory("silent_holds"))
+	mux.HandleFunc("GET "+p+"/silent_holds/{id}/advisory_notice", s.getNotice)
+	mux.HandleFunc("GET "+p+"/silent_holds/{id}/release_notice", s.getNotice)
+	mux.HandleFunc("GET "+p+"/silent_holds/{id}/advisory_copies", s.listAdvisoryCopies)
+	mux.HandleFunc("GET "+p+"/attachments/{id}", s.getAttachment)
+	mux.HandleFunc("POST "+p+"/silent_holds/import", s.importSilenthold)
+	mux.HandleFunc("POST "+p+"/silent_holds/release_custodians", s.releaseCustodians("silent_holds"))
+	mux.HandleFunc("GET "+p+"/questionnaires", s.listQuestionnaires)
+	mux.HandleFunc("GET "+p+"/questionnaires/{id}", s.getQuestionnaire)
+	mux.HandleFunc("DELETE "+p+"/questionnaires/{id}", s.deleteQuestionnaire)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Header: r.Header.Clone(),
+			Body:   body,
+		})
+		fault := s.matchFault(r)
+		s.mu.Unlock()
+
+		if fault != nil && writeFault(w, fault) {
+			return
+		}
+
+		if !strings.HasPrefix(r.URL.Path, s.base()+"/") {
+			writeError(w, http.StatusNotFound, "tenant not found")
+			return
+		}
+
+		if r.Header.Get("X-AUTH-TOKEN") != AUTH_TOKEN {
+			writeError(w, http.StatusUnauthorized, "invalid auth token")
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for _, f := range s.faults {
+		if f.matches(r) {
+			f.hits++
+			return f
+		}
+	}
+	return nil
+}
+
+// writeFault applies f and reports whether it answered the request.
+func writeFault(w http.ResponseWriter, f *Fault) bool {
+	time.Sleep(f.Delay)
+
+	if f.CloseConnection {
+		if hj, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hj.Hijack(); err == nil {
+				conn.Close()
+				return true
+			}
+		}
+	}
+
+	if f.StatusCode == 0 {
+		return false
+	}
+
+	for k, v := range f.Header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(f.StatusCode)
+	io.WriteString(w, f.Body)
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]any{"error": msg})
+}
+
+// pathID parses the {id} wildcard, writing a 404 when it is not a number.
+func pathID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return 0, false
+	}
+	return id, true
+}
+
+/*
+writeList writes one page of items in the format of DefaultEntityListInfo,
+honoring the filter[name], filter[term], page_size and page_number query
+parameters.
+*/
+func writeList[T any](w http.ResponseWriter, r *http.Request, key string, items []T, name func(T) string) {
+	q := r.URL.Query()
+
+	var filtered []T
+	for _, item := range items {
+		n := strings.ToLower(name(item))
+		if f := q.Get("filter[name]"); f != "" && !strings.Contains(n, strings.ToLower(f)) {
+			continue
+		}
+		if f := q.Get("filter[term]"); f != "" && !strings.Contains(n, strings.ToLower(f)) {
+			continue
+		}
+		filtered = append(filtered, item)
+	}
+
+	pageSize, _ := strconv.Atoi(q.Get("page_size"))
+	if pageSize <= 0 {
+		pageSize = DEFAULT_PAGE_SIZE
+	}
+	pageNumber, _ := strconv.Atoi(q.Get("page_number"))
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+
+	start := min((pageNumber-1)*pageSize, len(filtered))
+	end := min(start+pageSize, len(filtered))
+
+	page := filtered[start:end]
+	if page == nil {
+		page = []T{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"_links": map[string]any{
+			"self": map[string]string{"href": r.URL.RequestURI()},
+		},
+		"page": map[string]any{
+			"has-more":    end < len(filtered),
+			"total-count": len(filtered),
+		},
+		"_embedded": map[string]any{
+			key: page,
+		},
+	})
+}
+
+func findByID[T any](items []T, id int, getID func(T) int) (int, bool) {
+	for i, item := range items {
+		if getID(item) == id {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func writeEntity[T any](w http.ResponseWriter, r *http.Request, s *Server, items *[]T, getID func(T) int) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, ok := findByID(*items, id, getID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, (*items)[i])
+}
+
+/*
+removeEntity deletes the entity with the path id from items and answers 204.
+Entities rejected by canDelete, when given, are answered with 422.
+*/
+func removeEntity[T any](w http.ResponseWriter, r *http.Request, s *Server, items *[]T, getID func(T) int, canDelete func(T) bool) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, ok := findByID(*items, id, getID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if canDelete != nil && !canDelete((*items)[i]) {
+		writeError(w, http.StatusUnprocessableEntity, "can't be deleted")
+		return
+	}
+
+	*items = slices.Delete(*items, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func custodianID(c otlh.Custodian) int                { return c.ID }
+func custodianName(c otlh.Custodian) string           { return c.Name }
+func custodianGroupID(g otlh.CustodianGroup) int      { return g.ID }
+func custodianGroupName(g otlh.CustodianGroup) string { return g.Name }
+func folderID(f otlh.Folder) int                      { return f.ID }
+func folderName(f otlh.Folder) string                 { return f.Name }
+func groupID(g otlh.Group) int                        { return g.ID }
+func groupName(g otlh.Group) string                   { return g.Name }
+func matterID(m otlh.Matter) int                      { return m.ID }
+func matterName(m otlh.Matter) string                 { return m.Name }
+func legalholdID(h otlh.Legalhold) int                { return h.ID }
+func legalholdName(h otlh.Legalhold) string           { return h.Name }
+func silentholdID(h otlh.Silenthold) int              { return h.ID }
+func silentholdName(h otlh.Silenthold) string         { return h.Name }
+func advisoryCopyName(c otlh.AdvisoryCopy) string     { return c.Recipient.Name }
+func historyEventName(e otlh.HistoryEvent) string     { return e.Event }
+func questionnaireID(q otlh.Questionnaire) int        { return q.ID }
+func questionnaireName(q otlh.Questionnaire) string   { return q.Name }
+
+func (s *Server) listCustodians(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeList(w, r, "custodians", s.custodians, custodianName)
+}
+
+func (s *Server) getCustodian(w http.ResponseWriter, r *http.Request) {
+	writeEntity(w, r, s, &s.custodians, custodianID)
+}
+
+func (s *Server) deleteCustodian(w http.ResponseWriter, r *http.Request) {
+	removeEntity(w, r, s, &s.custodians, custodianID, nil)
+}
+
+func (s *Server) listCustodianGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeList(w, r, "custodian_groups", s.custodianGroups, custodianGroupName)
+}
+
+func (s *Server) getCustodianGroup(w http.ResponseWriter, r *http.Request) {
+	writeEntity(w, r, s, &s.custodianGroups, custodianGroupID)
+}
+
+func (s *Server) deleteCustodianGroup(w http.ResponseWriter, r *http.Request) {
+	removeEntity(w, r, s, &s.custodianGroups, custodianGroupID, nil)
+}
+
+func (s *Server) listCustodianGroupsOfCustodian(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var groups []otlh.CustodianGroup
+	for _, g := range s.custodianGroups {
+		if slices.Contains(s.members[fmt.Sprintf("custodian_groups/%d", g.ID)], id) {
+			groups = append(groups, g)
+		}
+	}
+	writeList(w, r, "custodian_groups", groups, custodianGroupName)
+}
+
+// listMembers lists the custodians attached to an entity of collection.
+func (s *Server) listMembers(collection string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writeList(w, r, "custodians", s.membersOf(fmt.Sprintf("%s/%d", collection, id)), custodianName)
+	}
+}
+
+// listHistory lists the events of a hold of collection.
+func (s *Server) listHistory(collection string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writeList(w, r, "history", s.history[fmt.Sprintf("%s/%d", collection, id)], historyEventName)
+	}
+}
+
+// getNotice serves the notice set for the requested link, see SetNotice.
+func (s *Server) getNotice(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notice, ok := s.noticeTexts[r.URL.Path]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, notice)
+}
+
+func (s *Server) listAdvisoryCopies(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeList(w, r, "advisory_copies", s.advisoryCopies[id], advisoryCopyName)
+}
+
+func (s *Server) getAttachment(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content, ok := s.attachments[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(content)
+}
+
+func (s *Server) membersOf(keys ...string) []otlh.Custodian {
+	var custodians []otlh.Custodian
+	for _, c := range s.custodians {
+		for _, key := range keys {
+			if slices.Contains(s.members[key], c.ID) {
+				custodians = append(custodians, c)
+				break
+			}
+		}
+	}
+	return custodians
+}
+
+/*
+listWhere lists the items related to the entity in the {id} wildcard, those
+for which related returns true. related is called with the server locked.
+*/
+func listWhere[T any](s *Server, key string, items *[]T, name func(T) string, related func(id int, item T) bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		var list []T
+		for _, item := range *items {
+			if related(id, item) {
+				list = append(list, item)
+			}
+		}
+		writeList(w, r, key, list, name)
+	}
+}
+
+func (s *Server) matterInFolder(folderID int, m otlh.Matter) bool {
+	return s.matterFolder[m.ID] == folderID
+}
+
+func (s *Server) groupHasFolder(folderID int, g otlh.Group) bool {
+	return slices.Contains(s.groupFolders[g.ID], folderID)
+}
+
+func legalholdOfMatter(matterID int, h otlh.Legalhold) bool {
+	return h.MatterID == matterID
+}
+
+func (s *Server) legalholdHasCustodian(custodianID int, h otlh.Legalhold) bool {
+	return slices.Contains(s.members[fmt.Sprintf("legal_holds/%d", h.ID)], custodianID)
+}
+
+func (s *Server) matterHasCustodian(custodianID int, m otlh.Matter) bool {
+	if slices.Contains(s.members[fmt.Sprintf("matters/%d", m.ID)], custodianID) {
+		return true
+	}
+	for _, h := range s.legalholds {
+		if h.MatterID == m.ID && s.legalholdHasCustodian(custodianID, h) {
+			return true
+		}
+	}
+	for _, h := range s.silentholds {
+		if h.MatterID == m.ID && slices.Contains(s.members[fmt.Sprintf("silent_holds/%d", h.ID)], custodianID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) listMatterCustodians(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := []string{fmt.Sprintf("matters/%d", id)}
+	for _, h := range s.legalholds {
+		if h.MatterID == id {
+			keys = append(keys, fmt.Sprintf("legal_holds/%d", h.ID))
+		}
+	}
+	for _, h := range s.silentholds {
+		if h.MatterID == id {
+			keys = append(keys, fmt.Sprintf("silent_holds/%d", h.ID))
+		}
+	}
+
+	writeList(w, r, "custodians", s.membersOf(keys...), custodianName)
+}
+
+func (s *Server) importCustodians(w http.ResponseWriter, r *http.Request) {
+	var body otlh.CustodianSyncBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.custodianImports = append(s.custodianImports, body.Custodians)
+
+	var errs []string
+	for _, in := range body.Custodians {
+		if in.Email == "" || in.Name == "" {
+			errs = append(errs, fmt.Sprintf("custodian [%s] requires name and email", in.Email))
+			continue
+		}
+
+		data, _ := json.Marshal(in)
+		if i, ok := s.custodianIndexByEmail(in.Email); ok {
+			json.Unmarshal(data, &s.custodians[i])
+			continue
+		}
+
+		var c otlh.Custodian
+		json.Unmarshal(data, &c)
+		s.addCustodian(c)
+	}
+
+	if len(errs) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"errors": errs})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) custodianIndexByEmail(email string) (int, bool) {
+	for i, c := range s.custodians {
+		if strings.EqualFold(c.Email, email) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (s *Server) listFolders(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeList(w, r, "folders", s.folders, folderName)
+}
+
+func (s *Server) getFolder(w http.ResponseWriter, r *http.Request) {
+	writeEntity(w, r, s, &s.folders, folderID)
+}
+
+func (s *Server) deleteFolder(w http.ResponseWriter, r *http.Request) {
+	removeEntity(w, r, s, &s.folders, folderID, func(f otlh.Folder) bool { return f.CanBeDeleted })
+}
+
+func (s *Server) listFoldersOfGroup(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var folders []otlh.Folder
+	for _, f := range s.folders {
+		if slices.Contains(s.groupFolders[id], f.ID) {
+			folders = append(folders, f)
+		}
+	}
+	writeList(w, r, "folders", folders, folderName)
+}
+
+func (s *Server) createFolder(w http.ResponseWriter, r *http.Request) {
+	var body otlh.CreateFolderBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if body.Name == "" {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"errors": map[string]any{"name": []string{"can't be blank"}}})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	folder := s.addFolder(otlh.Folder{Name: body.Name, InheritEmailConfig: body.InheritEmailConfig, CanBeDeleted: true})
+	for _, gid := range body.GroupIDs {
+		s.groupFolders[gid] = append(s.groupFolders[gid], folder.ID)
+	}
+
+	writeJSON(w, http.StatusOK, folder)
+}
+
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeList(w, r, "groups", s.groups, groupName)
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request) {
+	writeEntity(w, r, s, &s.groups, groupID)
+}
+
+func (s *Server) listMatters(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeList(w, r, "matters", s.matters, matterName)
+}
+
+func (s *Server) getMatter(w http.ResponseWriter, r *http.Request) {
+	writeEntity(w, r, s, &s.matters, matterID)
+}
+
+func (s *Server) deleteMatter(w http.ResponseWriter, r *http.Request) {
+	removeEntity(w, r, s, &s.matters, matterID, func(m otlh.Matter) bool { return m.CanBeDeleted })
+}
+
+func (s *Server) createMatter(w http.ResponseWriter, r *http.Request) {
+	var body otlh.CreateMatterBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if body.Name == "" {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"errors": map[string]any{"name": []string{"can't be blank"}}})
+		return
+	}
+
+	if _, ok := findByID(s.folders, body.FolderID, folderID); !ok {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"errors": map[string]any{"folder_id": []string{"is invalid"}}})
+		return
+	}
+
+	matter := s.addMatter(otlh.Matter{Name: body.Name, InheritEmailConfig: body.InheritEmailConfig, CanBeDeleted: true}, body.FolderID)
+	writeJSON(w, http.StatusOK, matter)
+}
+
+func (s *Server) updateMatter(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, ok := findByID(s.matters, id, matterID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if err := json.Unmarshal(body, &s.matters[i]); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.matters[i].ID = id
+
+	writeJSON(w, http.StatusOK, s.matters[i])
+}
+
+func (s *Server) listLegalholds(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeList(w, r, "legal_holds", s.legalholds, legalholdName)
+}
+
+func (s *Server) getLegalhold(w http.ResponseWriter, r *http.Request) {
+	writeEntity(w, r, s, &s.legalholds, legalholdID)
+}
+
+/*
+noticeCounts returns the counts set for a legal hold with SetLegalholdStats.
+By default every custodian on the hold has been issued the notice and has not
+acknowledged it yet.
+*/
+func (s *Server) noticeCounts(h otlh.Legalhold) otlh.NoticeCounts {
+	if counts, ok := s.legalholdStats[h.ID]; ok {
+		return counts
+	}
+
+	n := len(s.members[fmt.Sprintf("legal_holds/%d", h.ID)])
+	return otlh.NoticeCounts{Issued: n, Pending: n}
+}
+
+func (s *Server) getLegalholdStats(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, ok := findByID(s.legalholds, id, legalholdID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, otlh.LegalholdStats{LegalholdID: id, NoticeCounts: s.noticeCounts(s.legalholds[i])})
+}
+
+func (s *Server) getMatterStats(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := findByID(s.matters, id, matterID); !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	stats := otlh.MatterStats{MatterID: id}
+	custodians := map[int]bool{}
+	for _, h := range s.legalholds {
+		if h.MatterID != id {
+			continue
+		}
+		stats.LegalHolds++
+		stats.NoticeCounts = stats.NoticeCounts.Add(s.noticeCounts(h))
+		for _, cid := range s.members[fmt.Sprintf("legal_holds/%d", h.ID)] {
+			custodians[cid] = true
+		}
+	}
+	stats.Custodians = len(custodians)
+
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func (s *Server) listSilentholds(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeList(w, r, "silent_holds", s.silentholds, silentholdName)
+}
+
+func (s *Server) getSilenthold(w http.ResponseWriter, r *http.Request) {
+	writeEntity(w, r, s, &s.silentholds, silentholdID)
+}
+
+func (s *Server) listQuestionnaires(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeList(w, r, "questionnaires", s.questionnaires, questionnaireName)
+}
+
+func (s *Server) getQuestionnaire(w http.ResponseWriter, r *http.Request) {
+	writeEntity(w, r, s, &s.questionnaires, questionnaireID)
+}
+
+func (s *Server) deleteQuestionnaire(w http.ResponseWriter, r *http.Request) {
+	removeEntity(w, r, s, &s.questionnaires, questionnaireID, nil)
+}
+
+// readPackage reads and decodes the hold package uploaded as field.
+func readPackage(r *http.Request, field string, workbook string) (HoldPackage, error) {
+	file, _, err := r.FormFile(field)
+	if err != nil {
+		return HoldPackage{}, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return HoldPackage{}, err
+	}
+
+	return ParseHoldPackage(data, workbook)
+}
+
+/*
+holdFromPackage validates the package against the in-memory state and
+returns the matter id and the ids of the listed custodians.
+*/
+func (s *Server) holdFromPackage(pkg HoldPackage) (int, []int, []string) {
+	var errs []string
+
+	mid, err := strconv.Atoi(pkg.Detail("Matter id"))
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("invalid matter id [%s]", pkg.Detail("Matter id")))
+	} else if _, ok := findByID(s.matters, mid, matterID); !ok {
+		errs = append(errs, fmt.Sprintf("matter [%d] not found", mid))
+	}
+
+	if pkg.Detail("Hold Name") == "" {
+		errs = append(errs, "hold name can't be blank")
+	}
+
+	var ids []int
+	for _, email := range pkg.CustodianEmails() {
+		i, ok := s.custodianIndexByEmail(email)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("custodian [%s] not found", email))
+			continue
+		}
+		ids = append(ids, s.custodians[i].ID)
+	}
+
+	return mid, ids, errs
+}
+
+func (s *Server) importLegalhold(w http.ResponseWriter, r *http.Request) {
+	pkg, err := readPackage(r, "legal_hold_details", "legal_hold_details.xlsx")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.legalholdImports = append(s.legalholdImports, pkg)
+
+	mid, custodianIDs, errs := s.holdFromPackage(pkg)
+	if len(errs) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"errors": errs})
+		return
+	}
+
+	hold := s.addLegalhold(otlh.Legalhold{
+		MatterID:        mid,
+		Name:            pkg.Detail("Hold Name"),
+		HoldDescription: pkg.Detail("Hold notice subject"),
+		Status:          "active",
+	})
+	s.members[fmt.Sprintf("legal_holds/%d", hold.ID)] = custodianIDs
+
+	notice := otlh.Notice{
+		Subject: pkg.Detail("Hold notice subject"),
+		Title:   pkg.Detail("Hold notice title"),
+		Body:    pkg.Detail("Hold notice body"),
+	}
+	for _, name := range slices.Sorted(maps.Keys(pkg.Attachments)) {
+		notice.Attachments = append(notice.Attachments, s.addAttachment(name, pkg.Attachments[name]))
+	}
+	s.setNotice(hold.Links.HoldNotice.Href, notice)
+
+	writeJSON(w, http.StatusOK, hold)
+}
+
+func (s *Server) importSilenthold(w http.ResponseWriter, r *http.Request) {
+	pkg, err := readPackage(r, "silent_hold_details", "silent_hold_details.xlsx")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.silentholdImports = append(s.silentholdImports, pkg)
+
+	mid, custodianIDs, errs := s.holdFromPackage(pkg)
+	if len(errs) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"errors": errs})
+		return
+	}
+
+	hold := s.addSilenthold(otlh.Silenthold{
+		MatterID: mid,
+		Name:     pkg.Detail("Hold Name"),
+		Status:   "active",
+	})
+	s.members[fmt.Sprintf("silent_holds/%d", hold.ID)] = custodianIDs
+
+	s.setNotice(hold.Links.AdvisoryNotice.Href, otlh.Notice{
+		Subject: pkg.Detail("Advisory notice subject"),
+		Title:   pkg.Detail("Advisory notice title"),
+		Body:    pkg.Detail("Advisory notice body"),
+	})
+
+	writeJSON(w, http.StatusOK, hold)
+}
+
+/*
+sendNotice records the body posted and, when it names a legal hold and
+custodians on it, adds a HISTORY_NOTICE_SENT event for each custodian.
+*/
+func (s *Server) sendNotice(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.notices = append(s.notices, json.RawMessage(body))
+
+	var req otlh.SendNoticeBody
+	if json.Unmarshal(body, &req) != nil || req.LegalholdID == 0 {
+		writeJSON(w, http.StatusOK, map[string]any{})
+		return
+	}
+
+	key := fmt.Sprintf("legal_holds/%d", req.LegalholdID)
+	if _, ok := findByID(s.legalholds, req.LegalholdID, legalholdID); !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	for _, id := range req.CustodianIDs {
+		if !slices.Contains(s.members[key], id) {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("custodian %d is not on legal hold %d", id, req.LegalholdID))
+			return
+		}
+	}
+
+	s.addCustodianEvents(key, otlh.HISTORY_NOTICE_SENT, req.CustodianIDs)
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+/*
+sendReminders records a reminder event for every custodian it would reach:
+acknowledgement reminders go to the pending custodians, hold reminders to
+those not released. Holds whose can_send_* field denies it are rejected.
+*/
+func (s *Server) sendReminders(t otlh.ReminderType) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req otlh.SendRemindersBody
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		i, ok := findByID(s.legalholds, req.LegalholdID, legalholdID)
+		if !ok {
+			writeError(w, http.StatusNotFound, "not found")
+			return
+		}
+		if ok, reason := s.legalholds[i].CanSendReminders(t); !ok {
+			writeError(w, http.StatusUnprocessableEntity, reason)
+			return
+		}
+
+		key := fmt.Sprintf("legal_holds/%d", req.LegalholdID)
+		history := otlh.HistoryEvents(s.history[key])
+
+		var ids []int
+		event := otlh.HISTORY_ACKNOWLEDGEMENT_REMINDER_SENT
+		switch t {
+		case otlh.ACKNOWLEDGEMENT_REMINDER:
+			ids = history.Pending(s.members[key])
+		case otlh.HOLD_REMINDER:
+			event = otlh.HISTORY_HOLD_REMINDER_SENT
+			released := history.Released(s.members[key])
+			for _, id := range s.members[key] {
+				if !slices.Contains(released, id) {
+					ids = append(ids, id)
+				}
+			}
+		}
+
+		s.addCustodianEvents(key, event, ids)
+		writeJSON(w, http.StatusOK, map[string]any{})
+	}
+}
+
+/*
+releaseCustodians records a released event, and a release_notice_sent event
+when asked to, for each custodian. Custodians that are not on the hold, or
+whose latest membership event is a release, are rejected.
+*/
+func (s *Server) releaseCustodians(collection string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req otlh.ReleaseCustodiansBody
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		id, found := req.LegalholdID, false
+		if collection == "legal_holds" {
+			_, found = findByID(s.legalholds, id, legalholdID)
+		} else {
+			id = req.SilentholdID
+			_, found = findByID(s.silentholds, id, silentholdID)
+		}
+		if !found {
+			writeError(w, http.StatusNotFound, "not found")
+			return
+		}
+
+		key := fmt.Sprintf("%s/%d", collection, id)
+		released := otlh.HistoryEvents(s.history[key]).Released(req.CustodianIDs)
+		for _, custodianID := range req.CustodianIDs {
+			if !slices.Contains(s.members[key], custodianID) {
+				writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("custodian %d is not on %s %d", custodianID, collection, id))
+				return
+			}
+			if slices.Contains(released, custodianID) {
+				writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("custodian %d is already released from %s %d", custodianID, collection, id))
+				return
+			}
+		}
+
+		s.addCustodianEvents(key, otlh.HISTORY_RELEASED, req.CustodianIDs)
+		if req.SendReleaseNotice {
+			s.addCustodianEvents(key, otlh.HISTORY_RELEASE_NOTICE_SENT, req.CustodianIDs)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{})
+	}
+}
+
+// addCustodianEvents adds event to the history of the hold with key for each custodian in ids.
+func (s *Server) addCustodianEvents(key string, event string, ids []int) {
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	for _, id := range ids {
+		e := otlh.HistoryEvent{Event: event, CreatedAt: now}
+		if i, ok := findByID(s.custodians, id, custodianID); ok {
+			e.Custodian.ID = id
+			e.Custodian.Name = s.custodians[i].Name
+			e.Custodian.Email = s.custodians[i].Email
+		}
+		e.PerformedBy.Name = "otlhtest"
+		e.PerformedBy.Type = "api"
+		s.addHistory(key, e)
+	}
+}
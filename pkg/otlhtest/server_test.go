@@ -0,0 +1,306 @@
+package otlhtest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestMain(m *testing.M) {
+	// keep the debug and trace output of the client out of the test output
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	os.Exit(m.Run())
+}
+
+// do sends a request to srv with the auth token of otlhtest and returns the response with its body read.
+func do(t *testing.T, srv *otlhtest.Server, method string, path string, body string) (*http.Response, []byte, error) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-AUTH-TOKEN", otlhtest.AUTH_TOKEN)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := srv.Server.Client().Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	return resp, data, err
+}
+
+func apiPath(format string, a ...any) string {
+	return fmt.Sprintf("/t/demo/api/%s", otlh.APIVERSION) + fmt.Sprintf(format, a...)
+}
+
+func TestServerRejectsWrongTenantAndToken(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	tests := []struct {
+		name  string
+		path  string
+		token string
+		want  int
+	}{
+		{name: "ok", path: apiPath("/folders"), token: otlhtest.AUTH_TOKEN, want: http.StatusOK},
+		{name: "other tenant", path: fmt.Sprintf("/t/other/api/%s/folders", otlh.APIVERSION), token: otlhtest.AUTH_TOKEN, want: http.StatusNotFound},
+		{name: "wrong token", path: apiPath("/folders"), token: "wrong", want: http.StatusUnauthorized},
+		{name: "no token", path: apiPath("/folders"), want: http.StatusUnauthorized},
+		{name: "unknown id", path: apiPath("/folders/1"), token: otlhtest.AUTH_TOKEN, want: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, srv.URL+tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("X-AUTH-TOKEN", tt.token)
+			}
+
+			resp, err := srv.Server.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.want {
+				t.Fatalf("got status %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerListPaging(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	for i := range 30 {
+		srv.AddFolder(otlh.Folder{Name: fmt.Sprintf("Folder %02d", i)})
+	}
+	srv.AddFolder(otlh.Folder{Name: "Acme"})
+
+	tests := []struct {
+		query       string
+		wantNames   []string
+		wantCount   int
+		wantTotal   int
+		wantHasMore bool
+	}{
+		{query: "", wantCount: otlhtest.DEFAULT_PAGE_SIZE, wantTotal: 31, wantHasMore: true},
+		{query: "page_number=2", wantCount: 6, wantTotal: 31},
+		{query: "page_size=10&page_number=3", wantNames: []string{"Folder 20", "Folder 21"}, wantCount: 10, wantTotal: 31, wantHasMore: true},
+		{query: "page_size=10&page_number=5", wantCount: 0, wantTotal: 31},
+		{query: "filter[name]=acme", wantNames: []string{"Acme"}, wantCount: 1, wantTotal: 1},
+		{query: "filter[term]=folder 1", wantNames: []string{"Folder 10"}, wantCount: 10, wantTotal: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run("?"+tt.query, func(t *testing.T) {
+			path := apiPath("/folders")
+			if tt.query != "" {
+				q, _ := url.ParseQuery(tt.query)
+				path += "?" + q.Encode()
+			}
+
+			resp, data, err := do(t, srv, http.MethodGet, path, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("got status %d: %s", resp.StatusCode, data)
+			}
+
+			var list struct {
+				otlh.DefaultEntityListInfo
+				Embedded struct {
+					Folders []otlh.Folder `json:"folders"`
+				} `json:"_embedded"`
+			}
+			if err = json.Unmarshal(data, &list); err != nil {
+				t.Fatal(err)
+			}
+
+			if n := len(list.Embedded.Folders); n != tt.wantCount {
+				t.Fatalf("got %d folders, want %d", n, tt.wantCount)
+			}
+			if list.Page.TotalCount != tt.wantTotal || list.Page.HasMore != tt.wantHasMore {
+				t.Fatalf("got total-count %d and has-more %v, want %d and %v", list.Page.TotalCount, list.Page.HasMore, tt.wantTotal, tt.wantHasMore)
+			}
+			for i, name := range tt.wantNames {
+				if list.Embedded.Folders[i].Name != name {
+					t.Fatalf("got folder %q at %d, want %q", list.Embedded.Folders[i].Name, i, name)
+				}
+			}
+		})
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	tests := []struct {
+		name       string
+		fault      otlhtest.Fault
+		method     string
+		wantStatus []int
+		wantErr    bool
+		wantHeader string
+		minElapsed time.Duration
+	}{
+		{
+			name:       "status for every matching request",
+			fault:      otlhtest.Fault{Method: http.MethodGet, Path: "/folders", StatusCode: http.StatusServiceUnavailable},
+			method:     http.MethodGet,
+			wantStatus: []int{503, 503, 503},
+		},
+		{
+			name:       "Times limits the faulted requests",
+			fault:      otlhtest.Fault{Path: "/folders", StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"3"}}, Times: 2},
+			method:     http.MethodGet,
+			wantStatus: []int{429, 429, 200},
+			wantHeader: "3",
+		},
+		{
+			name:       "other method is not faulted",
+			fault:      otlhtest.Fault{Method: http.MethodPost, Path: "/folders", StatusCode: http.StatusInternalServerError},
+			method:     http.MethodGet,
+			wantStatus: []int{200},
+		},
+		{
+			name:       "delay without status",
+			fault:      otlhtest.Fault{Path: "/folders", Delay: 30 * time.Millisecond, Times: 1},
+			method:     http.MethodGet,
+			wantStatus: []int{200},
+			minElapsed: 30 * time.Millisecond,
+		},
+		{
+			name:    "dropped connection",
+			fault:   otlhtest.Fault{Path: "/folders", CloseConnection: true, Times: 1},
+			method:  http.MethodGet,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := otlhtest.NewServer("demo")
+			defer srv.Close()
+			srv.InjectFault(tt.fault)
+
+			start := time.Now()
+			if tt.wantErr {
+				if _, _, err := do(t, srv, tt.method, apiPath("/folders"), ""); err == nil {
+					t.Fatal("got a response, want the connection dropped")
+				}
+				if n := len(srv.Requests()); n != 1 {
+					t.Fatalf("got %d requests recorded, want 1", n)
+				}
+				return
+			}
+
+			for i, want := range tt.wantStatus {
+				resp, data, err := do(t, srv, tt.method, apiPath("/folders"), "")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if resp.StatusCode != want {
+					t.Fatalf("request %d: got status %d, want %d: %s", i+1, resp.StatusCode, want, data)
+				}
+				if i == 0 && tt.wantHeader != "" && resp.Header.Get("Retry-After") != tt.wantHeader {
+					t.Fatalf("got Retry-After %q, want %q", resp.Header.Get("Retry-After"), tt.wantHeader)
+				}
+			}
+			if elapsed := time.Since(start); elapsed < tt.minElapsed {
+				t.Fatalf("took %v, want at least %v", elapsed, tt.minElapsed)
+			}
+
+			srv.ClearFaults()
+			if resp, _, err := do(t, srv, tt.method, apiPath("/folders"), ""); err != nil || resp.StatusCode != http.StatusOK {
+				t.Fatalf("got %v after ClearFaults, want status 200", err)
+			}
+		})
+	}
+}
+
+func TestServerRecordsRequests(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+	group := srv.AddGroup(otlh.Group{Name: "All Admins"})
+
+	body := fmt.Sprintf(`{"name":"Acme","group_ids":[%d]}`, group.ID)
+	resp, data, err := do(t, srv, http.MethodPost, apiPath("/folders"), body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d: %s", resp.StatusCode, data)
+	}
+
+	var created otlh.Folder
+	if err = json.Unmarshal(data, &created); err != nil {
+		t.Fatal(err)
+	}
+	if folders := srv.Folders(); len(folders) != 1 || folders[0].ID != created.ID || folders[0].Name != "Acme" {
+		t.Fatalf("got folders %+v, want the created Acme", folders)
+	}
+
+	if _, _, err = do(t, srv, http.MethodGet, apiPath("/folders?page_size=5"), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	if r := requests[0]; r.Method != http.MethodPost || r.Path != apiPath("/folders") || string(r.Body) != body {
+		t.Fatalf("got first request %s %s %s, want the POST with its body", r.Method, r.Path, r.Body)
+	}
+	if r := requests[1]; r.Query.Get("page_size") != "5" || r.Header.Get("X-AUTH-TOKEN") != otlhtest.AUTH_TOKEN {
+		t.Fatalf("got second request query %v, want page_size 5 and the auth header", r.Query)
+	}
+
+	resp, _, err = do(t, srv, http.MethodDelete, apiPath("/folders/%d", created.ID), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode >= 300 || len(srv.Folders()) != 0 {
+		t.Fatalf("got status %d and %d folders after DELETE, want the folder removed", resp.StatusCode, len(srv.Folders()))
+	}
+}
+
+func TestServerClient(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+	srv.AddFolder(otlh.Folder{Name: "Acme"})
+
+	client := srv.Client()
+	if err := client.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	folder, err := client.FindFolderByName("Acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if folder.Name != "Acme" {
+		t.Fatalf("got folder %q, want Acme", folder.Name)
+	}
+
+	_, err = client.FindFolderByName("Missing")
+	if !errors.Is(err, otlh.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, otlh.ErrNotFound)
+	}
+}
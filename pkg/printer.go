@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"strings"
 )
 
@@ -48,3 +49,46 @@ func (jb *JSONPrinter) Print(v interface{}) error {
 
 	return nil
 }
+
+/*
+PrintSeq prints the entities of seq as a JSON array formatted like Print, one
+entity at a time as the iterator yields them, so long lists are shown while
+they are still being fetched. On error the array is closed and the error
+returned.
+*/
+func PrintSeq[T any](jb *JSONPrinter, seq iter.Seq2[T, error]) error {
+	var count int
+
+	for v, err := range seq {
+		if err != nil {
+			closeArray(count)
+			return err
+		}
+
+		b, err := json.MarshalIndent(v, jb.indent, jb.indent)
+		if err != nil {
+			closeArray(count)
+			return err
+		}
+
+		if count == 0 {
+			fmt.Print("[\n")
+		} else {
+			fmt.Print(",\n")
+		}
+		fmt.Print(jb.indent)
+		fmt.Print(string(b))
+		count++
+	}
+
+	closeArray(count)
+	return nil
+}
+
+func closeArray(count int) {
+	if count == 0 {
+		fmt.Println("[]")
+		return
+	}
+	fmt.Print("\n]\n")
+}
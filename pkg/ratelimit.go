@@ -0,0 +1,200 @@
+package otlh
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+/*
+RateLimiter is a token bucket limiting the number of requests sent per second.
+It is safe for concurrent use and can be shared by several clients talking to
+the same tenant.
+
+The limiter adapts to the server: every 429 response halves the current rate
+and pauses the bucket for the Retry-After period, every successful response
+moves the rate back up by a tenth of the rate it recovers to. A limiter without
+a configured rate lets every request through until the first 429, then starts
+from the rate the requests were sent at and lifts the limit again once it has
+recovered to it.
+*/
+type RateLimiter struct {
+	mu sync.Mutex
+
+	// limit is the configured rate in requests per second, +Inf when there is
+	// none, rate is the current (possibly reduced) rate. peak is the rate the
+	// limiter recovers to before the rate is set back to limit: limit itself,
+	// or without one the rate observed when the limiter was throttled.
+	limit float64
+	rate  float64
+	peak  float64
+	burst float64
+
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+
+	// sent counts the requests let through since windowStart, previous the
+	// ones of the second before it, to estimate the rate of an unlimited
+	// limiter.
+	windowStart time.Time
+	sent        int
+	previous    int
+}
+
+/*
+NewRateLimiter creates a limiter allowing rps requests per second with bursts
+of up to burst requests. A burst smaller than 1 is treated as 1. A rate of 0 or
+less sets no limit until the server answers 429 Too Many Requests.
+*/
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	if rps <= 0 {
+		rps = math.Inf(1)
+	}
+	b := math.Max(float64(burst), 1)
+	now := time.Now()
+	return &RateLimiter{
+		limit:       rps,
+		rate:        rps,
+		peak:        rps,
+		burst:       b,
+		tokens:      b,
+		last:        now,
+		windowStart: now,
+	}
+}
+
+// Rate returns the current rate in requests per second, +Inf while unlimited.
+func (l *RateLimiter) Rate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rate
+}
+
+/*
+Wait blocks until a request may be sent or ctx is done. A nil limiter never
+blocks.
+*/
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		wait := l.reserve()
+		if wait <= 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// reserve takes a token if one is available, otherwise it returns how long
+// the caller should wait before trying again.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+
+	if math.IsInf(l.rate, 1) {
+		l.count(now)
+		return 0
+	}
+
+	l.refill(now)
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	if now.After(l.last) && !math.IsInf(l.rate, 1) {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+	}
+}
+
+// throttle is called when the server answered 429 Too Many Requests.
+func (l *RateLimiter) throttle(retryAfter time.Duration) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if math.IsInf(l.rate, 1) {
+		l.peak = math.Max(l.observed(now), 1)
+		l.rate = l.peak
+	} else {
+		l.refill(now)
+	}
+	l.rate = math.Max(l.rate/2, l.peak/16)
+	l.tokens, l.last = 0, now
+
+	// the first request after the Retry-After period is sent at once, the
+	// following ones at the reduced rate
+	if until := now.Add(retryAfter); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+		l.tokens, l.last = 1, until
+	}
+}
+
+// relax is called after a successful response.
+func (l *RateLimiter) relax() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate < l.limit {
+		l.refill(time.Now())
+		if l.rate += l.peak / 10; l.rate >= l.peak {
+			l.rate = l.limit
+		}
+	}
+}
+
+// count records a request let through while the limiter is unlimited.
+func (l *RateLimiter) count(now time.Time) {
+	l.advance(now)
+	l.sent++
+}
+
+// observed estimates the number of requests let through in the last second.
+func (l *RateLimiter) observed(now time.Time) float64 {
+	l.advance(now)
+	part := now.Sub(l.windowStart).Seconds()
+	return float64(l.previous)*(1-part) + float64(l.sent)
+}
+
+// advance moves the one second counting window forward to now.
+func (l *RateLimiter) advance(now time.Time) {
+	elapsed := now.Sub(l.windowStart)
+	if elapsed < time.Second {
+		return
+	}
+
+	l.previous = 0
+	if elapsed < 2*time.Second {
+		l.previous = l.sent
+	}
+	l.sent = 0
+	l.windowStart = now.Add(-(elapsed % time.Second))
+}
@@ -0,0 +1,163 @@
+package otlh_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	tests := []struct {
+		name       string
+		rps        float64
+		burst      int
+		waits      int
+		minElapsed time.Duration
+		maxElapsed time.Duration
+	}{
+		{name: "steady rate", rps: 100, burst: 1, waits: 11, minElapsed: 90 * time.Millisecond},
+		{name: "burst", rps: 1, burst: 5, waits: 5, maxElapsed: 500 * time.Millisecond},
+		{name: "burst below 1", rps: 100, burst: 0, waits: 6, minElapsed: 40 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := otlh.NewRateLimiter(tt.rps, tt.burst)
+
+			start := time.Now()
+			for range tt.waits {
+				if err := limiter.Wait(context.Background()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			elapsed := time.Since(start)
+
+			if elapsed < tt.minElapsed {
+				t.Fatalf("%d waits took %v, want at least %v", tt.waits, elapsed, tt.minElapsed)
+			}
+			if tt.maxElapsed > 0 && elapsed > tt.maxElapsed {
+				t.Fatalf("%d waits took %v, want at most %v", tt.waits, elapsed, tt.maxElapsed)
+			}
+		})
+	}
+}
+
+func TestRateLimiterWaitContextDone(t *testing.T) {
+	limiter := otlh.NewRateLimiter(0.1, 1)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	var none *otlh.RateLimiter
+	if err := none.Wait(ctx); err != nil {
+		t.Fatalf("got %v from a nil limiter, want nil", err)
+	}
+}
+
+func TestRateLimiterAdaptsTo429(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+	srv.InjectFault(otlhtest.Fault{
+		Method:     http.MethodGet,
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"0"}},
+		Times:      2,
+	})
+
+	const rps = 1000
+	limiter := otlh.NewRateLimiter(rps, 1)
+	client := srv.ClientBuilder().WithRateLimiter(limiter).Build()
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Folder().Build()
+
+	// two 429s halve the rate twice, the successful retry raises it by a tenth
+	if _, err := client.GetFolders(req); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := limiter.Rate(), rps/4+rps/10.0; got != want {
+		t.Fatalf("got rate %v after two 429 responses, want %v", got, want)
+	}
+
+	for range 10 {
+		if _, err := client.GetFolders(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := limiter.Rate(); got != rps {
+		t.Fatalf("got rate %v after successful responses, want it back at %v", got, float64(rps))
+	}
+}
+
+func TestRateLimiterSharedByClients(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	limiter := otlh.NewRateLimiter(100, 1)
+	clients := []*otlh.Client{
+		srv.ClientBuilder().WithRateLimiter(limiter).Build(),
+		srv.ClientBuilder().WithRateLimiter(limiter).Build(),
+	}
+
+	start := time.Now()
+	for i := range 6 {
+		client := clients[i%2]
+		req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Folder().Build()
+		if _, err := client.GetFolders(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the first request takes the burst token, the other five wait 10ms each
+	if elapsed := time.Since(start); elapsed < 45*time.Millisecond {
+		t.Fatalf("6 requests at 100/s took %v, want the clients to share the limit", elapsed)
+	}
+}
+
+func TestRateLimiterWithoutLimitAdaptsTo429(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	client := srv.Client()
+	limiter := client.RateLimiter()
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Folder().Build()
+
+	const sent = 50
+	for range sent {
+		if _, err := client.GetFolders(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := limiter.Rate(); !math.IsInf(got, 1) {
+		t.Fatalf("got rate %v before any 429 response, want no limit", got)
+	}
+
+	// the 429 halves the rate the requests were sent at, the successful retry raises it by a tenth
+	srv.InjectFault(otlhtest.Fault{Method: http.MethodGet, StatusCode: http.StatusTooManyRequests, Times: 1})
+	if _, err := client.GetFolders(req); err != nil {
+		t.Fatal(err)
+	}
+	if got := limiter.Rate(); got < 0.6 || got > (sent+1)*0.6 {
+		t.Fatalf("got rate %v after a 429 response, want 0.6 of at most %d requests per second", got, sent+1)
+	}
+
+	for range 5 {
+		if _, err := client.GetFolders(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := limiter.Rate(); !math.IsInf(got, 1) {
+		t.Fatalf("got rate %v after successful responses, want the limit lifted", got)
+	}
+}
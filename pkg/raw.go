@@ -0,0 +1,120 @@
+package otlh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+/*
+RawRequest addresses any endpoint of the API by its path, for endpoints that
+have no request builder of their own.
+*/
+type RawRequest struct {
+	method   Method
+	endpoint string
+}
+
+/*
+NewRawRequest returns a request for path on tenant. A path starting with /t/
+is used as is, e.g. a _links href; any other path is taken relative to the
+tenant's API root, so "matters/12/stats" becomes /t/<tenant>/api/v3/matters/12/stats.
+The placeholders {tenant} and {version} are replaced in either form.
+*/
+func NewRawRequest(method Method, tenant string, path string) *RawRequest {
+	path = strings.NewReplacer("{tenant}", tenant, "{version}", APIVERSION).Replace(path)
+
+	if !strings.HasPrefix(path, "/t/") {
+		path = fmt.Sprintf("/t/%s/api/%s/%s", tenant, APIVERSION, strings.TrimPrefix(path, "/"))
+	}
+
+	return &RawRequest{method: method, endpoint: path}
+}
+
+func (req *RawRequest) Method() Method {
+	return req.method
+}
+
+func (req *RawRequest) Endpoint() string {
+	return req.endpoint
+}
+
+// unmarshalRawPage keeps a list page as it is, reading only its paging info.
+func unmarshalRawPage(body []byte) ([]json.RawMessage, bool, int, error) {
+	var info DefaultEntityListInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, false, 0, err
+	}
+	return []json.RawMessage{body}, info.Page.HasMore, info.Page.TotalCount, nil
+}
+
+/*
+GetAllPages fetches every page of the list at req and merges them into one
+response: the first page with the arrays under _embedded of all pages joined
+and page.has-more cleared. A response without paging info is returned as is.
+
+It is meant for endpoints without a typed GetAll* method, e.g. _links targets.
+*/
+func (c *Client) GetAllPages(req Requestor, opts *ListOptions) ([]byte, error) {
+	return c.GetAllPagesContext(context.Background(), req, opts)
+}
+
+// GetAllPagesContext is like GetAllPages but carries ctx.
+func (c *Client) GetAllPagesContext(ctx context.Context, req Requestor, opts *ListOptions) ([]byte, error) {
+	var pages []json.RawMessage
+	var total int
+
+	err := walkEntities(ctx, c, req, opts, unmarshalRawPage, func(page []json.RawMessage, totalCount int) bool {
+		pages = append(pages, page...)
+		total = totalCount
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pages) == 1 {
+		return pages[0], nil
+	}
+
+	return mergePages(pages, total)
+}
+
+func mergePages(pages []json.RawMessage, total int) ([]byte, error) {
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(pages[0], &merged); err != nil {
+		return nil, err
+	}
+
+	embedded := map[string][]json.RawMessage{}
+
+	for i, page := range pages {
+		var p struct {
+			Embedded map[string]json.RawMessage `json:"_embedded"`
+		}
+		if err := json.Unmarshal(page, &p); err != nil {
+			return nil, fmt.Errorf("page %d: %w", i+1, err)
+		}
+
+		for key, value := range p.Embedded {
+			var items []json.RawMessage
+			if err := json.Unmarshal(value, &items); err != nil {
+				return nil, fmt.Errorf("page %d: _embedded.%s is not an array", i+1, key)
+			}
+			embedded[key] = append(embedded[key], items...)
+		}
+	}
+
+	var err error
+	if merged["_embedded"], err = json.Marshal(embedded); err != nil {
+		return nil, err
+	}
+
+	pageInfo := map[string]any{"has-more": false, "total-count": total}
+	if merged["page"], err = json.Marshal(pageInfo); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(merged)
+}
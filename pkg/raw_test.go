@@ -0,0 +1,134 @@
+package otlh_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestNewRawRequest(t *testing.T) {
+	root := "/t/demo/api/" + otlh.APIVERSION
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "matters/12/stats", want: root + "/matters/12/stats"},
+		{path: "/matters/12/stats", want: root + "/matters/12/stats"},
+		{path: "legal_holds?filter[name]=Acme", want: root + "/legal_holds?filter[name]=Acme"},
+		{path: "/t/demo/api/v3/folders/7", want: "/t/demo/api/v3/folders/7"},
+		{path: "/t/other/api/v2/folders", want: "/t/other/api/v2/folders"},
+		{path: "/t/{tenant}/api/{version}/folders", want: root + "/folders"},
+		{path: "reports/{tenant}-{version}", want: root + "/reports/demo-" + otlh.APIVERSION},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := otlh.NewRawRequest(otlh.DELETE, "demo", tt.path)
+			if req.Endpoint() != tt.want {
+				t.Fatalf("got endpoint %s, want %s", req.Endpoint(), tt.want)
+			}
+			if req.Method() != otlh.DELETE {
+				t.Fatalf("got method %s, want DELETE", req.Method())
+			}
+		})
+	}
+}
+
+func TestGetAllPages(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	var folders otlh.Folders
+	for i := range 23 {
+		folders = append(folders, srv.AddFolder(otlh.Folder{Name: fmt.Sprintf("Folder %02d", i)}))
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		fault        *otlhtest.Fault
+		wantFolders  int
+		wantRequests int
+		wantErr      string
+	}{
+		{
+			name:         "merged pages",
+			path:         "folders",
+			wantFolders:  23,
+			wantRequests: 3,
+		},
+		{
+			name:         "single object",
+			path:         fmt.Sprintf("folders/%d", folders[0].ID),
+			wantRequests: 1,
+		},
+		{
+			name: "_embedded entry that is not an array",
+			path: "folders",
+			fault: &otlhtest.Fault{
+				Method:     http.MethodGet,
+				Path:       "/folders",
+				StatusCode: http.StatusOK,
+				Body:       `{"_embedded": {"folders": {"id": 1}}, "page": {"has-more": true, "total-count": 23}}`,
+				Times:      1,
+			},
+			wantRequests: 3,
+			wantErr:      "page 1: _embedded.folders is not an array",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fault != nil {
+				srv.InjectFault(*tt.fault)
+				defer srv.ClearFaults()
+			}
+
+			client := srv.Client()
+			sent := len(srv.Requests())
+
+			data, err := client.GetAllPages(otlh.NewRawRequest(otlh.GET, client.Tenant(), tt.path), otlh.NewListOptions().WithPageSize(10))
+
+			if n := len(srv.Requests()) - sent; n != tt.wantRequests {
+				t.Fatalf("got %d requests, want %d", n, tt.wantRequests)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var list struct {
+				otlh.DefaultEntityListInfo
+				Embedded struct {
+					Folders []otlh.Folder `json:"folders"`
+				} `json:"_embedded"`
+			}
+			if err = json.Unmarshal(data, &list); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(list.Embedded.Folders) != tt.wantFolders {
+				t.Fatalf("got %d folders, want %d", len(list.Embedded.Folders), tt.wantFolders)
+			}
+			if list.Page.HasMore {
+				t.Fatal("got has-more true in the merged response")
+			}
+			for i, folder := range list.Embedded.Folders {
+				if want := fmt.Sprintf("Folder %02d", i); folder.Name != want {
+					t.Fatalf("got folder %q at %d, want %q", folder.Name, i, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package otlh
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/rs/zerolog/log"
+)
+
+/*
+ReleaseCustodiansBody releases custodians from the legal hold or silent hold
+of LegalholdID or SilentholdID, sending them the release notice of the hold
+when SendReleaseNotice is set.
+*/
+type ReleaseCustodiansBody struct {
+	LegalholdID       int   `json:"legal_hold_id,omitempty"`
+	SilentholdID      int   `json:"silent_hold_id,omitempty"`
+	CustodianIDs      []int `json:"custodian_ids"`
+	SendReleaseNotice bool  `json:"send_release_notice"`
+}
+
+func NewReleaseCustodiansBody() *ReleaseCustodiansBody {
+	return &ReleaseCustodiansBody{}
+}
+
+func (b *ReleaseCustodiansBody) WithLegalholdID(id int) *ReleaseCustodiansBody {
+	b.LegalholdID = id
+	return b
+}
+
+func (b *ReleaseCustodiansBody) WithSilentholdID(id int) *ReleaseCustodiansBody {
+	b.SilentholdID = id
+	return b
+}
+
+func (b *ReleaseCustodiansBody) WithCustodianIDs(ids []int) *ReleaseCustodiansBody {
+	b.CustodianIDs = ids
+	return b
+}
+
+func (b *ReleaseCustodiansBody) WithSendReleaseNotice(send bool) *ReleaseCustodiansBody {
+	b.SendReleaseNotice = send
+	return b
+}
+
+/*
+ReleaseLegalholdCustodians releases custodians from a legal hold and, with
+sendNotice, sends them its release notice. Releasing can not be undone; the
+service rejects custodians that are not on the hold.
+*/
+func (c *Client) ReleaseLegalholdCustodians(legalholdID int, custodianIDs []int, sendNotice bool) error {
+	return c.ReleaseLegalholdCustodiansContext(context.Background(), legalholdID, custodianIDs, sendNotice)
+}
+
+// ReleaseLegalholdCustodiansContext is like ReleaseLegalholdCustodians but carries ctx.
+func (c *Client) ReleaseLegalholdCustodiansContext(ctx context.Context, legalholdID int, custodianIDs []int, sendNotice bool) error {
+	req, _ := NewRequest().WithTenant(c.tenant).Post().Legalhold().ReleaseCustodians().Build()
+	body, _ := json.Marshal(NewReleaseCustodiansBody().WithLegalholdID(legalholdID).WithCustodianIDs(custodianIDs).WithSendReleaseNotice(sendNotice))
+
+	if _, err := c.SendContext(ctx, req, NewBodyOptions().WithBody(string(body))); err != nil {
+		return err
+	}
+
+	log.Debug().Msgf("released %d custodians from legal hold %d", len(custodianIDs), legalholdID)
+	return nil
+}
+
+// ReleaseSilentholdCustodians is like ReleaseLegalholdCustodians for a silent hold.
+func (c *Client) ReleaseSilentholdCustodians(silentholdID int, custodianIDs []int, sendNotice bool) error {
+	return c.ReleaseSilentholdCustodiansContext(context.Background(), silentholdID, custodianIDs, sendNotice)
+}
+
+// ReleaseSilentholdCustodiansContext is like ReleaseSilentholdCustodians but carries ctx.
+func (c *Client) ReleaseSilentholdCustodiansContext(ctx context.Context, silentholdID int, custodianIDs []int, sendNotice bool) error {
+	req, _ := NewRequest().WithTenant(c.tenant).Post().Silenthold().ReleaseCustodians().Build()
+	body, _ := json.Marshal(NewReleaseCustodiansBody().WithSilentholdID(silentholdID).WithCustodianIDs(custodianIDs).WithSendReleaseNotice(sendNotice))
+
+	if _, err := c.SendContext(ctx, req, NewBodyOptions().WithBody(string(body))); err != nil {
+		return err
+	}
+
+	log.Debug().Msgf("released %d custodians from silent hold %d", len(custodianIDs), silentholdID)
+	return nil
+}
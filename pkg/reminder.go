@@ -0,0 +1,110 @@
+package otlh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ReminderType selects the reminders sent for a legal hold.
+type ReminderType int
+
+const (
+	ACKNOWLEDGEMENT_REMINDER ReminderType = iota + 1
+	HOLD_REMINDER
+)
+
+func (t ReminderType) String() string {
+	switch t {
+	case ACKNOWLEDGEMENT_REMINDER:
+		return "acknowledgement"
+	case HOLD_REMINDER:
+		return "hold"
+	default:
+		return fmt.Sprintf("ReminderType(%d)", int(t))
+	}
+}
+
+// ParseReminderType returns the ReminderType named s, acknowledgement or hold.
+func ParseReminderType(s string) (ReminderType, error) {
+	switch s {
+	case "acknowledgement":
+		return ACKNOWLEDGEMENT_REMINDER, nil
+	case "hold":
+		return HOLD_REMINDER, nil
+	default:
+		return 0, fmt.Errorf("unsupported reminder type %s, use acknowledgement or hold", s)
+	}
+}
+
+/*
+CanSendReminders reports whether reminders of type t may be sent for the hold
+according to its can_send_acknowledgement_reminders or can_send_hold_reminders
+field, and the reason the service gives when not. A code of 0 allows sending.
+*/
+func (h Legalhold) CanSendReminders(t ReminderType) (bool, string) {
+	code, reason := h.CanSendAcknowledgementReminders.Code, h.CanSendAcknowledgementReminders.Reason
+	if t == HOLD_REMINDER {
+		code, reason = h.CanSendHoldReminders.Code, h.CanSendHoldReminders.Reason
+	}
+
+	if code == 0 {
+		return true, ""
+	}
+	if reason == "" {
+		reason = fmt.Sprintf("code %d", code)
+	}
+	return false, reason
+}
+
+type SendRemindersBody struct {
+	LegalholdID int `json:"legal_hold_id"`
+}
+
+func NewSendRemindersBody() *SendRemindersBody {
+	return &SendRemindersBody{}
+}
+
+func (b *SendRemindersBody) WithLegalholdID(id int) *SendRemindersBody {
+	b.LegalholdID = id
+	return b
+}
+
+/*
+SendLegalholdReminders sends reminders of type t to the custodians of a legal
+hold: acknowledgement reminders go to those that have not acknowledged the
+hold notice, hold reminders to everyone still on hold. When the hold does not
+allow it, nothing is sent and the error wraps ErrRemindersNotAllowed with the
+reason.
+*/
+func (c *Client) SendLegalholdReminders(legalhold Legalhold, t ReminderType) error {
+	return c.SendLegalholdRemindersContext(context.Background(), legalhold, t)
+}
+
+// SendLegalholdRemindersContext is like SendLegalholdReminders but carries ctx.
+func (c *Client) SendLegalholdRemindersContext(ctx context.Context, legalhold Legalhold, t ReminderType) error {
+	if ok, reason := legalhold.CanSendReminders(t); !ok {
+		return fmt.Errorf("%s reminders of legal hold %d: %w: %s", t, legalhold.ID, ErrRemindersNotAllowed, reason)
+	}
+
+	builder := NewRequest().WithTenant(c.tenant).Post().Legalhold()
+	switch t {
+	case ACKNOWLEDGEMENT_REMINDER:
+		builder.SendAcknowledgementReminders()
+	case HOLD_REMINDER:
+		builder.SendHoldReminders()
+	default:
+		return fmt.Errorf("unsupported reminder type %s", t)
+	}
+	req, _ := builder.Build()
+	body, _ := json.Marshal(NewSendRemindersBody().WithLegalholdID(legalhold.ID))
+
+	if _, err := c.SendContext(ctx, req, NewBodyOptions().WithBody(string(body))); err != nil {
+		return err
+	}
+
+	log.Debug().Msgf("sent %s reminders of legal hold %d", t, legalhold.ID)
+	return nil
+}
@@ -0,0 +1,161 @@
+package otlh_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+// denyReminders returns h with reminders of type t denied by code and reason.
+func denyReminders(h otlh.Legalhold, t otlh.ReminderType, code int, reason string) otlh.Legalhold {
+	if t == otlh.HOLD_REMINDER {
+		h.CanSendHoldReminders.Code, h.CanSendHoldReminders.Reason = code, reason
+	} else {
+		h.CanSendAcknowledgementReminders.Code, h.CanSendAcknowledgementReminders.Reason = code, reason
+	}
+	return h
+}
+
+func TestCanSendReminders(t *testing.T) {
+	tests := []struct {
+		name       string
+		legalhold  otlh.Legalhold
+		t          otlh.ReminderType
+		want       bool
+		wantReason string
+	}{
+		{name: "allowed", t: otlh.ACKNOWLEDGEMENT_REMINDER, want: true},
+		{
+			name:       "denied with a reason",
+			legalhold:  denyReminders(otlh.Legalhold{}, otlh.ACKNOWLEDGEMENT_REMINDER, 2, "the hold is released"),
+			t:          otlh.ACKNOWLEDGEMENT_REMINDER,
+			wantReason: "the hold is released",
+		},
+		{
+			name:       "denied without a reason",
+			legalhold:  denyReminders(otlh.Legalhold{}, otlh.HOLD_REMINDER, 3, ""),
+			t:          otlh.HOLD_REMINDER,
+			wantReason: "code 3",
+		},
+		{
+			name:      "hold reminders are not denied by the acknowledgement field",
+			legalhold: denyReminders(otlh.Legalhold{}, otlh.ACKNOWLEDGEMENT_REMINDER, 2, "no acknowledgement requested"),
+			t:         otlh.HOLD_REMINDER,
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, reason := tt.legalhold.CanSendReminders(tt.t)
+			if ok != tt.want || reason != tt.wantReason {
+				t.Fatalf("got %v, %q, want %v, %q", ok, reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestSendLegalholdReminders(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	jane := srv.AddCustodian(otlh.Custodian{Name: "Jane Doe", Email: "jane@acme.com"})
+	john := srv.AddCustodian(otlh.Custodian{Name: "John Doe", Email: "john@acme.com"})
+
+	legalhold := srv.AddLegalhold(otlh.Legalhold{Name: "Acme"}, jane.ID, john.ID)
+	srv.AddLegalholdHistory(legalhold.ID, custodianEvent(jane.ID, otlh.HISTORY_ACKNOWLEDGED, "2026-01-02T00:00:00Z"))
+
+	// the service denies hold reminders, the copy the client holds does not know it yet
+	denied := srv.AddLegalhold(denyReminders(otlh.Legalhold{Name: "Denied"}, otlh.HOLD_REMINDER, 2, "hold reminders are disabled"), jane.ID)
+	stale := denied
+	stale.CanSendHoldReminders.Code = 0
+
+	client := srv.ClientBuilder().WithRetryPolicy(otlh.RetryPolicy{MaxAttempts: 1}).Build()
+
+	tests := []struct {
+		name          string
+		legalhold     otlh.Legalhold
+		t             otlh.ReminderType
+		wantReminded  []int
+		wantRequests  int
+		wantErr       error
+		wantAPIStatus int
+	}{
+		{
+			name:         "acknowledgement reminders to the pending custodians",
+			legalhold:    legalhold,
+			t:            otlh.ACKNOWLEDGEMENT_REMINDER,
+			wantReminded: []int{john.ID},
+			wantRequests: 1,
+		},
+		{
+			name:         "hold reminders to everyone on hold",
+			legalhold:    legalhold,
+			t:            otlh.HOLD_REMINDER,
+			wantReminded: []int{jane.ID, john.ID},
+			wantRequests: 1,
+		},
+		{
+			name:      "denied by the hold",
+			legalhold: denied,
+			t:         otlh.HOLD_REMINDER,
+			wantErr:   otlh.ErrRemindersNotAllowed,
+		},
+		{
+			name:          "denied by the service",
+			legalhold:     stale,
+			t:             otlh.HOLD_REMINDER,
+			wantRequests:  1,
+			wantAPIStatus: 422,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := len(srv.Requests())
+			err := client.SendLegalholdReminders(tt.legalhold, tt.t)
+
+			if n := len(srv.Requests()) - sent; n != tt.wantRequests {
+				t.Fatalf("got %d requests, want %d", n, tt.wantRequests)
+			}
+
+			var apiErr *otlh.APIError
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+				return
+			case tt.wantAPIStatus != 0:
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantAPIStatus {
+					t.Fatalf("got error %v, want status %d", err, tt.wantAPIStatus)
+				}
+				return
+			case err != nil:
+				t.Fatal(err)
+			}
+
+			history, err := client.GetLegalholdHistory(tt.legalhold)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event := otlh.HISTORY_ACKNOWLEDGEMENT_REMINDER_SENT
+			if tt.t == otlh.HOLD_REMINDER {
+				event = otlh.HISTORY_HOLD_REMINDER_SENT
+			}
+			var reminded []int
+			for _, e := range history {
+				if e.Event == event {
+					reminded = append(reminded, e.Custodian.ID)
+				}
+			}
+			if !slices.Equal(reminded, tt.wantReminded) {
+				t.Fatalf("got %s events for %v, want %v", event, reminded, tt.wantReminded)
+			}
+		})
+	}
+}
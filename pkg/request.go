@@ -1,13 +1,46 @@
 package otlh
 
+import (
+	"fmt"
+	"strings"
+)
+
 type Method int
 
 const (
 	GET Method = iota
 	POST
 	PATCH
+	PUT
+	DELETE
 )
 
+func (m Method) String() string {
+	switch m {
+	case GET:
+		return "GET"
+	case POST:
+		return "POST"
+	case PATCH:
+		return "PATCH"
+	case PUT:
+		return "PUT"
+	case DELETE:
+		return "DELETE"
+	}
+	return "UNKNOWN"
+}
+
+// ParseMethod converts an HTTP method name, in any case, to a Method.
+func ParseMethod(name string) (Method, error) {
+	for _, m := range []Method{GET, POST, PATCH, PUT, DELETE} {
+		if strings.EqualFold(name, m.String()) {
+			return m, nil
+		}
+	}
+	return GET, fmt.Errorf("unsupported method %s, use one of GET, POST, PATCH, PUT, DELETE", name)
+}
+
 type Requestor interface {
 	Method() Method
 	Endpoint() string
@@ -46,6 +79,16 @@ func (req *Request) Patch() *Request {
 	return req
 }
 
+func (req *Request) Put() *Request {
+	req.method = PUT
+	return req
+}
+
+func (req *Request) Delete() *Request {
+	req.method = DELETE
+	return req
+}
+
 func (req *Request) Custodian() *CustodianRequestBuilder {
 	return &CustodianRequestBuilder{CustodianRequest: &CustodianRequest{Request: *req}}
 }
@@ -0,0 +1,172 @@
+package otlh
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	DEFAULT_MAX_ATTEMPTS    = 4
+	DEFAULT_INITIAL_BACKOFF = 500 * time.Millisecond
+	DEFAULT_MAX_BACKOFF     = 30 * time.Second
+)
+
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+/*
+RetryPolicy controls how Client.Send retries failed requests.
+
+MaxAttempts is the total number of attempts including the first one, so a
+value of 1 disables retries. The wait between attempts grows exponentially
+from InitialBackoff up to MaxBackoff with up to Jitter (0..1) of the delay
+randomized. A Retry-After header returned by the server takes precedence
+over the computed delay, but is capped at MaxBackoff as well.
+
+Only idempotent requests are retried on RetryableStatusCodes or transport
+errors. Non-idempotent requests (POST, PATCH) are retried only when the
+connection could not be established, i.e. the request provably never
+reached the server.
+*/
+type RetryPolicy struct {
+	MaxAttempts          int
+	InitialBackoff       time.Duration
+	MaxBackoff           time.Duration
+	Jitter               float64
+	RetryableStatusCodes []int
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:          DEFAULT_MAX_ATTEMPTS,
+		InitialBackoff:       DEFAULT_INITIAL_BACKOFF,
+		MaxBackoff:           DEFAULT_MAX_BACKOFF,
+		Jitter:               0.2,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+	}
+}
+
+// NoRetryPolicy returns a policy that sends every request exactly once.
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// isIdempotent reports whether sending m twice has the same effect as sending it once.
+func isIdempotent(m Method) bool {
+	return m == GET || m == PUT || m == DELETE
+}
+
+// isConnectError reports whether err happened before any byte of the request
+// was written, e.g. DNS resolution or TCP dial failures.
+func isConnectError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial" || opErr.Op == "proxyconnect"
+	}
+
+	return false
+}
+
+/*
+shouldRetry decides whether another attempt should be made after attempt
+number attempt (starting at 1) finished with resp and err.
+
+Returns:
+- bool: true if the request should be sent again
+- time.Duration: how long to wait before the next attempt
+*/
+func (p RetryPolicy) shouldRetry(m Method, attempt int, resp *resty.Response, err error) (bool, time.Duration) {
+	if attempt >= p.MaxAttempts {
+		return false, 0
+	}
+
+	if err != nil {
+		if isConnectError(err) || isIdempotent(m) {
+			return true, p.backoff(attempt)
+		}
+		return false, 0
+	}
+
+	if resp == nil || !isIdempotent(m) || !slices.Contains(p.RetryableStatusCodes, resp.StatusCode()) {
+		return false, 0
+	}
+
+	if wait, ok := p.retryAfter(resp); ok {
+		return true, wait
+	}
+
+	return true, p.backoff(attempt)
+}
+
+// backoff returns the exponential delay before attempt+1, with jitter applied.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		d -= d * p.Jitter * rand.Float64()
+	}
+
+	return time.Duration(d)
+}
+
+/*
+retryAfter returns the wait asked for by the Retry-After header of resp, at
+most MaxBackoff, or DEFAULT_MAX_BACKOFF when the policy has none, so a server
+asking for a day can not stall the client.
+*/
+func (p RetryPolicy) retryAfter(resp *resty.Response) (time.Duration, bool) {
+	wait, ok := parseRetryAfter(resp.Header().Get("Retry-After"))
+	if !ok {
+		return 0, false
+	}
+
+	limit := p.MaxBackoff
+	if limit <= 0 {
+		limit = DEFAULT_MAX_BACKOFF
+	}
+	return min(wait, limit), true
+}
+
+// parseRetryAfter parses a Retry-After header in either delay-seconds or
+// HTTP-date form.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,214 @@
+package otlh_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestRetry(t *testing.T) {
+	fastRetries := otlh.RetryPolicy{
+		MaxAttempts:          3,
+		InitialBackoff:       time.Millisecond,
+		MaxBackoff:           10 * time.Millisecond,
+		RetryableStatusCodes: otlh.DefaultRetryableStatusCodes,
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		fault        otlhtest.Fault
+		policy       otlh.RetryPolicy
+		wantRequests int
+		wantStatus   int
+		minElapsed   time.Duration
+		maxElapsed   time.Duration
+	}{
+		{
+			name:         "GET recovers from 503",
+			method:       http.MethodGet,
+			fault:        otlhtest.Fault{StatusCode: http.StatusServiceUnavailable, Times: 2},
+			policy:       fastRetries,
+			wantRequests: 3,
+		},
+		{
+			name:         "GET gives up after MaxAttempts",
+			method:       http.MethodGet,
+			fault:        otlhtest.Fault{StatusCode: http.StatusBadGateway},
+			policy:       fastRetries,
+			wantRequests: 3,
+			wantStatus:   http.StatusBadGateway,
+		},
+		{
+			name:         "GET is not retried on 400",
+			method:       http.MethodGet,
+			fault:        otlhtest.Fault{StatusCode: http.StatusBadRequest},
+			policy:       fastRetries,
+			wantRequests: 1,
+			wantStatus:   http.StatusBadRequest,
+		},
+		{
+			name:         "GET recovers from a dropped connection",
+			method:       http.MethodGet,
+			fault:        otlhtest.Fault{CloseConnection: true, Times: 1},
+			policy:       fastRetries,
+			wantRequests: 2,
+		},
+		{
+			name:         "POST is not retried on 503",
+			method:       http.MethodPost,
+			fault:        otlhtest.Fault{StatusCode: http.StatusServiceUnavailable},
+			policy:       fastRetries,
+			wantRequests: 1,
+			wantStatus:   http.StatusServiceUnavailable,
+		},
+		{
+			name:         "POST is not retried once the connection was made",
+			method:       http.MethodPost,
+			fault:        otlhtest.Fault{CloseConnection: true, Times: 1},
+			policy:       fastRetries,
+			wantRequests: 1,
+			wantStatus:   -1,
+		},
+		{
+			name:         "NoRetryPolicy sends once",
+			method:       http.MethodGet,
+			fault:        otlhtest.Fault{StatusCode: http.StatusServiceUnavailable, Times: 1},
+			policy:       otlh.NoRetryPolicy(),
+			wantRequests: 1,
+			wantStatus:   http.StatusServiceUnavailable,
+		},
+		{
+			name:   "backoff doubles",
+			method: http.MethodGet,
+			fault:  otlhtest.Fault{StatusCode: http.StatusServiceUnavailable, Times: 2},
+			policy: otlh.RetryPolicy{
+				MaxAttempts:          3,
+				InitialBackoff:       20 * time.Millisecond,
+				MaxBackoff:           time.Second,
+				RetryableStatusCodes: otlh.DefaultRetryableStatusCodes,
+			},
+			wantRequests: 3,
+			minElapsed:   60 * time.Millisecond,
+		},
+		{
+			name:   "Retry-After takes precedence",
+			method: http.MethodGet,
+			fault: otlhtest.Fault{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"1"}},
+				Times:      1,
+			},
+			policy: otlh.RetryPolicy{
+				MaxAttempts:          3,
+				InitialBackoff:       time.Millisecond,
+				MaxBackoff:           2 * time.Second,
+				RetryableStatusCodes: otlh.DefaultRetryableStatusCodes,
+			},
+			wantRequests: 2,
+			minElapsed:   time.Second,
+		},
+		{
+			name:   "Retry-After capped at MaxBackoff",
+			method: http.MethodGet,
+			fault: otlhtest.Fault{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"86400"}},
+				Times:      1,
+			},
+			policy:       fastRetries,
+			wantRequests: 2,
+			maxElapsed:   time.Second,
+		},
+		{
+			name:   "Retry-After date capped at MaxBackoff",
+			method: http.MethodGet,
+			fault: otlhtest.Fault{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{"Retry-After": []string{time.Now().Add(24 * time.Hour).UTC().Format(http.TimeFormat)}},
+				Times:      1,
+			},
+			policy:       fastRetries,
+			wantRequests: 2,
+			maxElapsed:   time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := otlhtest.NewServer("demo")
+			defer srv.Close()
+			group := srv.AddGroup(otlh.Group{Name: "All Admins"})
+
+			tt.fault.Method = tt.method
+			tt.fault.Path = "/folders"
+			srv.InjectFault(tt.fault)
+
+			client := srv.ClientBuilder().WithRetryPolicy(tt.policy).Build()
+
+			start := time.Now()
+			var err error
+			if tt.method == http.MethodGet {
+				req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Folder().Build()
+				_, err = client.GetFolders(req)
+			} else {
+				_, err = client.CreateFolder("Acme", []int{group.ID})
+			}
+			elapsed := time.Since(start)
+
+			var apiErr *otlh.APIError
+			switch {
+			case tt.wantStatus == 0 && err != nil:
+				t.Fatalf("got error %v, want success", err)
+			case tt.wantStatus > 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus):
+				t.Fatalf("got error %v, want status %d", err, tt.wantStatus)
+			case tt.wantStatus < 0 && err == nil:
+				t.Fatal("got no error, want a transport error")
+			}
+
+			if n := len(srv.Requests()); n != tt.wantRequests {
+				t.Fatalf("got %d requests, want %d", n, tt.wantRequests)
+			}
+			if elapsed < tt.minElapsed {
+				t.Fatalf("took %v, want at least %v", elapsed, tt.minElapsed)
+			}
+			if tt.maxElapsed > 0 && elapsed > tt.maxElapsed {
+				t.Fatalf("took %v, want at most %v", elapsed, tt.maxElapsed)
+			}
+		})
+	}
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+	srv.InjectFault(otlhtest.Fault{Method: http.MethodGet, StatusCode: http.StatusServiceUnavailable})
+
+	client := srv.ClientBuilder().WithRetryPolicy(otlh.RetryPolicy{
+		MaxAttempts:          5,
+		InitialBackoff:       time.Minute,
+		RetryableStatusCodes: otlh.DefaultRetryableStatusCodes,
+	}).Build()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Folder().Build()
+	start := time.Now()
+	_, err := client.GetFoldersContext(ctx, req)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("took %v, want the backoff cut short", elapsed)
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Fatalf("got %d requests, want 1", n)
+	}
+}
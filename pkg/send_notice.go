@@ -0,0 +1,205 @@
+package otlh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// NoticeRecipients selects which custodians of a legal hold a notice is sent to.
+type NoticeRecipients int
+
+const (
+	ALL_CUSTODIANS NoticeRecipients = iota + 1
+	PENDING_CUSTODIANS
+	SELECTED_CUSTODIANS
+)
+
+/*
+SendNoticeOptions selects the legal hold and the custodians to send its hold
+notice to: all of them (the default), those that have not acknowledged it
+yet, or those with the given ids or emails.
+*/
+type SendNoticeOptions struct {
+	legalholdID  int
+	recipients   NoticeRecipients
+	custodianIDs []int
+	emails       []string
+}
+
+func NewSendNoticeOptions(legalholdID int) *SendNoticeOptions {
+	return &SendNoticeOptions{legalholdID: legalholdID, recipients: ALL_CUSTODIANS}
+}
+
+func (opts *SendNoticeOptions) ToAllCustodians() *SendNoticeOptions {
+	opts.recipients = ALL_CUSTODIANS
+	return opts
+}
+
+// ToPendingCustodians sends the notice to the custodians that have neither acknowledged it nor been released.
+func (opts *SendNoticeOptions) ToPendingCustodians() *SendNoticeOptions {
+	opts.recipients = PENDING_CUSTODIANS
+	return opts
+}
+
+// ToCustodianIDs sends the notice to the custodians with ids, all of which must be on the hold.
+func (opts *SendNoticeOptions) ToCustodianIDs(ids ...int) *SendNoticeOptions {
+	opts.recipients = SELECTED_CUSTODIANS
+	opts.custodianIDs = append(opts.custodianIDs, ids...)
+	return opts
+}
+
+// ToEmails sends the notice to the custodians with emails, all of which must be on the hold.
+func (opts *SendNoticeOptions) ToEmails(emails ...string) *SendNoticeOptions {
+	opts.recipients = SELECTED_CUSTODIANS
+	opts.emails = append(opts.emails, emails...)
+	return opts
+}
+
+func (opts *SendNoticeOptions) LegalholdID() int {
+	return opts.legalholdID
+}
+
+type SendNoticeBody struct {
+	LegalholdID  int   `json:"legal_hold_id"`
+	CustodianIDs []int `json:"custodian_ids"`
+}
+
+func NewSendNoticeBody() *SendNoticeBody {
+	return &SendNoticeBody{}
+}
+
+func (b *SendNoticeBody) WithLegalholdID(id int) *SendNoticeBody {
+	b.LegalholdID = id
+	return b
+}
+
+func (b *SendNoticeBody) WithCustodianIDs(ids []int) *SendNoticeBody {
+	b.CustodianIDs = ids
+	return b
+}
+
+/*
+GetNoticeRecipients returns the legal hold of opts and the custodians its
+notice would be sent to, without sending anything. Custodian ids or emails
+that are not on the hold are an error.
+*/
+func (c *Client) GetNoticeRecipients(opts *SendNoticeOptions) (Legalhold, Custodians, error) {
+	return c.GetNoticeRecipientsContext(context.Background(), opts)
+}
+
+// GetNoticeRecipientsContext is like GetNoticeRecipients but carries ctx.
+func (c *Client) GetNoticeRecipientsContext(ctx context.Context, opts *SendNoticeOptions) (Legalhold, Custodians, error) {
+	req, _ := NewRequest().WithTenant(c.tenant).Get().Legalhold().WithID(opts.legalholdID).Build()
+	legalhold, err := c.GetLegalholdContext(ctx, req)
+	if err != nil {
+		return legalhold, nil, err
+	}
+
+	custodians, err := c.GetLegalholdCustodiansContext(ctx, legalhold)
+	if err != nil {
+		return legalhold, nil, err
+	}
+
+	switch opts.recipients {
+	case PENDING_CUSTODIANS:
+		history, err := c.GetLegalholdHistoryContext(ctx, legalhold)
+		if err != nil {
+			return legalhold, nil, err
+		}
+
+		ids := make([]int, len(custodians))
+		for i, custodian := range custodians {
+			ids[i] = custodian.ID
+		}
+		pending := history.Pending(ids)
+
+		custodians = slices.DeleteFunc(custodians, func(custodian Custodian) bool {
+			return !slices.Contains(pending, custodian.ID)
+		})
+
+	case SELECTED_CUSTODIANS:
+		var selected Custodians
+		var missing []string
+
+		for _, id := range opts.custodianIDs {
+			i := slices.IndexFunc(custodians, func(custodian Custodian) bool { return custodian.ID == id })
+			if i < 0 {
+				missing = append(missing, fmt.Sprint(id))
+			} else if !slices.ContainsFunc(selected, func(custodian Custodian) bool { return custodian.ID == id }) {
+				selected = append(selected, custodians[i])
+			}
+		}
+
+		for _, email := range opts.emails {
+			i := slices.IndexFunc(custodians, func(custodian Custodian) bool { return strings.EqualFold(custodian.Email, email) })
+			if i < 0 {
+				missing = append(missing, email)
+			} else if !slices.ContainsFunc(selected, func(custodian Custodian) bool { return custodian.ID == custodians[i].ID }) {
+				selected = append(selected, custodians[i])
+			}
+		}
+
+		if len(missing) > 0 {
+			return legalhold, nil, fmt.Errorf("custodians not on legal hold %d: %s", legalhold.ID, strings.Join(missing, ", "))
+		}
+		custodians = selected
+	}
+
+	return legalhold, custodians, nil
+}
+
+/*
+SendLegalholdNotice sends the hold notice of the legal hold of opts to the
+selected custodians and returns them. Sending can not be undone, use
+GetNoticeRecipients to review the recipients first. Nothing is sent when no
+custodian is selected.
+*/
+func (c *Client) SendLegalholdNotice(opts *SendNoticeOptions) (Custodians, error) {
+	return c.SendLegalholdNoticeContext(context.Background(), opts)
+}
+
+// SendLegalholdNoticeContext is like SendLegalholdNotice but carries ctx.
+func (c *Client) SendLegalholdNoticeContext(ctx context.Context, opts *SendNoticeOptions) (Custodians, error) {
+	legalhold, custodians, err := c.GetNoticeRecipientsContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(custodians) == 0 {
+		log.Debug().Msgf("no custodians selected on legal hold %d, notice not sent", legalhold.ID)
+		return custodians, nil
+	}
+
+	return custodians, c.SendLegalholdNoticeToContext(ctx, legalhold.ID, custodians)
+}
+
+/*
+SendLegalholdNoticeTo sends the hold notice of a legal hold to custodians as
+is, e.g. to the recipients returned by GetNoticeRecipients once reviewed.
+*/
+func (c *Client) SendLegalholdNoticeTo(legalholdID int, custodians Custodians) error {
+	return c.SendLegalholdNoticeToContext(context.Background(), legalholdID, custodians)
+}
+
+// SendLegalholdNoticeToContext is like SendLegalholdNoticeTo but carries ctx.
+func (c *Client) SendLegalholdNoticeToContext(ctx context.Context, legalholdID int, custodians Custodians) error {
+	ids := make([]int, len(custodians))
+	for i, custodian := range custodians {
+		ids[i] = custodian.ID
+	}
+
+	req, _ := NewRequest().WithTenant(c.tenant).Post().Legalhold().SendNotice().Build()
+	body, _ := json.Marshal(NewSendNoticeBody().WithLegalholdID(legalholdID).WithCustodianIDs(ids))
+
+	if _, err := c.SendContext(ctx, req, NewBodyOptions().WithBody(string(body))); err != nil {
+		return err
+	}
+
+	log.Debug().Msgf("sent hold notice of legal hold %d to %d custodians", legalholdID, len(ids))
+	return nil
+}
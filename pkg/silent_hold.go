@@ -82,13 +82,21 @@ func (b *SilentholdRequestBuilder) Import() *SilentholdRequestBuilder {
 	return b
 }
 
+func (b *SilentholdRequestBuilder) ReleaseCustodians() *SilentholdRequestBuilder {
+	b.action = RELEASE_CUSTODIANS
+	return b
+}
+
 func (b *SilentholdRequestBuilder) Build() (*SilentholdRequest, error) {
 	return b.SilentholdRequest, nil
 }
 
 func (req *SilentholdRequest) Endpoint() string {
-	if req.action == IMPORT {
+	switch req.action {
+	case IMPORT:
 		return fmt.Sprintf("/t/%s/api/%s/silent_holds/import", req.tenant, APIVERSION)
+	case RELEASE_CUSTODIANS:
+		return fmt.Sprintf("/t/%s/api/%s/silent_holds/release_custodians", req.tenant, APIVERSION)
 	}
 
 	if req.id > 0 {
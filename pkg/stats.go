@@ -0,0 +1,123 @@
+package otlh
+
+import "context"
+
+/*
+NoticeCounts counts the custodians of one or more holds by the state of their
+hold notice. Overdue custodians, those that have not acknowledged by the
+response due date, are also counted as Pending.
+*/
+type NoticeCounts struct {
+	Issued       int `json:"issued"`
+	Acknowledged int `json:"acknowledged"`
+	Pending      int `json:"pending"`
+	Released     int `json:"released"`
+	Overdue      int `json:"overdue"`
+}
+
+// Add returns the sum of n and o, e.g. to total the stats of several holds.
+func (n NoticeCounts) Add(o NoticeCounts) NoticeCounts {
+	return NoticeCounts{
+		Issued:       n.Issued + o.Issued,
+		Acknowledged: n.Acknowledged + o.Acknowledged,
+		Pending:      n.Pending + o.Pending,
+		Released:     n.Released + o.Released,
+		Overdue:      n.Overdue + o.Overdue,
+	}
+}
+
+/*
+LegalholdStats are the notice counts of a legal hold, as returned by the
+hold's stats link. LegalholdID and Name are filled in from the hold when the
+service leaves them out.
+*/
+type LegalholdStats struct {
+	LegalholdID int    `json:"legal_hold_id"`
+	Name        string `json:"name,omitempty"`
+	NoticeCounts
+}
+
+// MatterStats are the notice counts over all legal holds of a matter.
+type MatterStats struct {
+	MatterID   int    `json:"matter_id"`
+	Name       string `json:"name,omitempty"`
+	LegalHolds int    `json:"legal_holds"`
+	Custodians int    `json:"custodians"`
+	NoticeCounts
+}
+
+// GetLegalholdStats returns the notice counts of a legal hold.
+func (c *Client) GetLegalholdStats(legalhold Legalhold) (LegalholdStats, error) {
+	return c.GetLegalholdStatsContext(context.Background(), legalhold)
+}
+
+// GetLegalholdStatsContext is like GetLegalholdStats but carries ctx.
+func (c *Client) GetLegalholdStatsContext(ctx context.Context, legalhold Legalhold) (LegalholdStats, error) {
+	var stats LegalholdStats
+	if err := c.FollowContext(ctx, legalhold.Links.Stats.Href, &stats); err != nil {
+		return stats, err
+	}
+
+	if stats.LegalholdID == 0 {
+		stats.LegalholdID = legalhold.ID
+	}
+	if stats.Name == "" {
+		stats.Name = legalhold.Name
+	}
+	return stats, nil
+}
+
+// GetMatterStats returns the notice counts over all legal holds of a matter.
+func (c *Client) GetMatterStats(matter Matter) (MatterStats, error) {
+	return c.GetMatterStatsContext(context.Background(), matter)
+}
+
+// GetMatterStatsContext is like GetMatterStats but carries ctx.
+func (c *Client) GetMatterStatsContext(ctx context.Context, matter Matter) (MatterStats, error) {
+	var stats MatterStats
+	if err := c.FollowContext(ctx, matter.Links.Stats.Href, &stats); err != nil {
+		return stats, err
+	}
+
+	if stats.MatterID == 0 {
+		stats.MatterID = matter.ID
+	}
+	if stats.Name == "" {
+		stats.Name = matter.Name
+	}
+	return stats, nil
+}
+
+/*
+GetFolderLegalholdStats returns the stats of every legal hold of every matter
+in a folder, matter by matter. Total them with NoticeCounts.Add.
+*/
+func (c *Client) GetFolderLegalholdStats(folder Folder) ([]LegalholdStats, error) {
+	return c.GetFolderLegalholdStatsContext(context.Background(), folder)
+}
+
+// GetFolderLegalholdStatsContext is like GetFolderLegalholdStats but carries ctx.
+func (c *Client) GetFolderLegalholdStatsContext(ctx context.Context, folder Folder) ([]LegalholdStats, error) {
+	matters, err := c.GetFolderMattersContext(ctx, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []LegalholdStats
+	for _, matter := range matters {
+		legalholds, err := c.GetMatterLegalholdsContext(ctx, matter)
+		if err != nil {
+			return all, err
+		}
+
+		for _, legalhold := range legalholds {
+			stats, err := c.GetLegalholdStatsContext(ctx, legalhold)
+			if err != nil {
+				return all, err
+			}
+			all = append(all, stats)
+		}
+	}
+
+	return all, nil
+}
@@ -0,0 +1,142 @@
+package otlh_test
+
+import (
+	"net/http"
+	"testing"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestGetLegalholdStats(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	legalhold := srv.AddLegalhold(otlh.Legalhold{Name: "Acme"})
+	counts := otlh.NoticeCounts{Issued: 10, Acknowledged: 6, Pending: 3, Released: 1, Overdue: 2}
+	srv.SetLegalholdStats(legalhold.ID, counts)
+
+	tests := []struct {
+		name     string
+		body     string
+		wantID   int
+		wantName string
+		want     otlh.NoticeCounts
+	}{
+		{
+			name:     "name filled in",
+			wantID:   legalhold.ID,
+			wantName: "Acme",
+			want:     counts,
+		},
+		{
+			name:     "id and name filled in",
+			body:     `{"issued": 4, "pending": 4}`,
+			wantID:   legalhold.ID,
+			wantName: "Acme",
+			want:     otlh.NoticeCounts{Issued: 4, Pending: 4},
+		},
+		{
+			name:     "id and name of the service kept",
+			body:     `{"legal_hold_id": 99, "name": "Acme (renamed)", "issued": 1}`,
+			wantID:   99,
+			wantName: "Acme (renamed)",
+			want:     otlh.NoticeCounts{Issued: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.body != "" {
+				srv.InjectFault(otlhtest.Fault{Method: http.MethodGet, Path: "/stats", StatusCode: http.StatusOK, Body: tt.body, Times: 1})
+				defer srv.ClearFaults()
+			}
+
+			stats, err := srv.Client().GetLegalholdStats(legalhold)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stats.LegalholdID != tt.wantID || stats.Name != tt.wantName {
+				t.Fatalf("got hold %d [%s], want %d [%s]", stats.LegalholdID, stats.Name, tt.wantID, tt.wantName)
+			}
+			if stats.NoticeCounts != tt.want {
+				t.Fatalf("got counts %+v, want %+v", stats.NoticeCounts, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMatterStats(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	folder := srv.AddFolder(otlh.Folder{Name: "Acme"})
+	matter := srv.AddMatter(otlh.Matter{Name: "Contract"}, folder.ID)
+	jane := srv.AddCustodian(otlh.Custodian{Name: "Jane Doe"})
+	john := srv.AddCustodian(otlh.Custodian{Name: "John Doe"})
+	srv.AddLegalhold(otlh.Legalhold{Name: "First", MatterID: matter.ID}, jane.ID, john.ID)
+	srv.AddLegalhold(otlh.Legalhold{Name: "Second", MatterID: matter.ID}, jane.ID)
+
+	stats, err := srv.Client().GetMatterStats(matter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.MatterID != matter.ID || stats.Name != "Contract" {
+		t.Fatalf("got matter %d [%s], want %d [Contract]", stats.MatterID, stats.Name, matter.ID)
+	}
+	if stats.LegalHolds != 2 || stats.Custodians != 2 || stats.Issued != 3 {
+		t.Fatalf("got %d holds, %d custodians, %d issued, want 2, 2 and 3", stats.LegalHolds, stats.Custodians, stats.Issued)
+	}
+}
+
+func TestGetFolderLegalholdStats(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	folder := srv.AddFolder(otlh.Folder{Name: "Acme"})
+	other := srv.AddFolder(otlh.Folder{Name: "Other"})
+
+	contract := srv.AddMatter(otlh.Matter{Name: "Contract"}, folder.ID)
+	dispute := srv.AddMatter(otlh.Matter{Name: "Dispute"}, folder.ID)
+	elsewhere := srv.AddMatter(otlh.Matter{Name: "Elsewhere"}, other.ID)
+
+	holds := []struct {
+		matterID int
+		counts   otlh.NoticeCounts
+	}{
+		{contract.ID, otlh.NoticeCounts{Issued: 10, Acknowledged: 8, Pending: 2, Overdue: 1}},
+		{contract.ID, otlh.NoticeCounts{Issued: 5, Acknowledged: 1, Pending: 3, Released: 1}},
+		{dispute.ID, otlh.NoticeCounts{Issued: 7, Acknowledged: 7}},
+		{elsewhere.ID, otlh.NoticeCounts{Issued: 100, Pending: 100}},
+	}
+	var want []int
+	for _, h := range holds {
+		legalhold := srv.AddLegalhold(otlh.Legalhold{Name: "Hold", MatterID: h.matterID})
+		srv.SetLegalholdStats(legalhold.ID, h.counts)
+		if h.matterID != elsewhere.ID {
+			want = append(want, legalhold.ID)
+		}
+	}
+
+	all, err := srv.Client().GetFolderLegalholdStats(folder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(all) != len(want) {
+		t.Fatalf("got stats of %d holds, want %d", len(all), len(want))
+	}
+
+	var total otlh.NoticeCounts
+	for i, stats := range all {
+		if stats.LegalholdID != want[i] {
+			t.Fatalf("got hold %d at %d, want %d", stats.LegalholdID, i, want[i])
+		}
+		total = total.Add(stats.NoticeCounts)
+	}
+
+	if wantTotal := (otlh.NoticeCounts{Issued: 22, Acknowledged: 16, Pending: 5, Released: 1, Overdue: 1}); total != wantTotal {
+		t.Fatalf("got total %+v, want %+v", total, wantTotal)
+	}
+}
@@ -0,0 +1,66 @@
+package otlh
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const DEFAULT_MIN_TLS_VERSION = tls.VersionTLS12
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ParseTLSVersion converts a version such as "1.2" or "TLS1.3" to its crypto/tls constant.
+func ParseTLSVersion(version string) (uint16, error) {
+	v := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(version)), "TLS")
+	if id, ok := tlsVersions[strings.TrimSpace(v)]; ok {
+		return id, nil
+	}
+	return 0, fmt.Errorf("unsupported TLS version %s, use one of 1.0, 1.1, 1.2, 1.3", version)
+}
+
+/*
+tlsConfig builds the TLS settings of the client: the system roots plus the CA
+certificates of caCertFile, the client certificate, if any, and the minimum
+protocol version.
+*/
+func (c *Client) tlsConfig() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         c.minTLSVersion,
+		InsecureSkipVerify: c.skipVerify,
+	}
+
+	if c.caCertFile != "" {
+		pem, err := os.ReadFile(c.caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("CA certificate: %w", err)
+		}
+
+		// keep trusting the public CAs, the bundle only adds e.g. a proxy's CA
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA certificate: no PEM certificates found in %s", c.caCertFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if c.clientCertFile != "" || c.clientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.clientCertFile, c.clientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
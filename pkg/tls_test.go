@@ -0,0 +1,110 @@
+package otlh_test
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestParseTLSVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    uint16
+		wantErr bool
+	}{
+		{version: "1.2", want: tls.VersionTLS12},
+		{version: "TLS1.3", want: tls.VersionTLS13},
+		{version: " tls 1.3 ", want: tls.VersionTLS13},
+		{version: "1.4", wantErr: true},
+		{version: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := otlh.ParseTLSVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("got %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientTLSSettings(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	dir := t.TempDir()
+
+	caFile := filepath.Join(dir, "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	notPEM := filepath.Join(dir, "ca.txt")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		configure    func(*otlh.ClientBuilder)
+		wantBuildErr bool
+	}{
+		{
+			name:      "CA certificate file",
+			configure: func(b *otlh.ClientBuilder) { b.WithSkipVerify(false).WithCACertFile(caFile) },
+		},
+		{
+			name:         "missing CA certificate file",
+			configure:    func(b *otlh.ClientBuilder) { b.WithSkipVerify(false).WithCACertFile(filepath.Join(dir, "missing.pem")) },
+			wantBuildErr: true,
+		},
+		{
+			name:         "CA certificate file without PEM",
+			configure:    func(b *otlh.ClientBuilder) { b.WithSkipVerify(false).WithCACertFile(notPEM) },
+			wantBuildErr: true,
+		},
+		{
+			name:         "missing client key",
+			configure:    func(b *otlh.ClientBuilder) { b.WithClientCertificate(caFile, filepath.Join(dir, "missing.key")) },
+			wantBuildErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := srv.ClientBuilder()
+			tt.configure(b)
+			client := b.Build()
+			sent := len(srv.Requests())
+
+			if (client.Err() != nil) != tt.wantBuildErr {
+				t.Fatalf("got Err %v, want error %v", client.Err(), tt.wantBuildErr)
+			}
+
+			req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Folder().Build()
+			_, err := client.GetFolders(req)
+			if tt.wantBuildErr {
+				if err != client.Err() {
+					t.Fatalf("got request error %v, want the build error %v", err, client.Err())
+				}
+				if n := len(srv.Requests()) - sent; n != 0 {
+					t.Fatalf("got %d requests, want none sent", n)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package otlh
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const DEFAULT_TOKEN_TTL = 15 * time.Minute
+
+/*
+TokenSource supplies the auth token sent with every request. The client asks
+for the token before each attempt, so a source may rotate it at any time.
+*/
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+/*
+RefreshableTokenSource is a TokenSource caching its token. When the service
+answers 401 the client calls Invalidate and sends the request once more with
+a freshly obtained token.
+*/
+type RefreshableTokenSource interface {
+	TokenSource
+	Invalidate()
+}
+
+// StaticToken is a TokenSource always returning the same token.
+type StaticToken string
+
+func (t StaticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+// cachedToken holds the token of a refreshable source until it expires or is invalidated.
+type cachedToken struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	token   string
+	expires time.Time
+}
+
+func (c *cachedToken) get(load func() (string, error)) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && (c.ttl <= 0 || time.Now().Before(c.expires)) {
+		return c.token, nil
+	}
+
+	token, err := load()
+	if err != nil {
+		return "", err
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("empty auth token")
+	}
+
+	c.token = token
+	c.expires = time.Now().Add(c.ttl)
+	return token, nil
+}
+
+func (c *cachedToken) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = ""
+}
+
+/*
+FileTokenSource reads the token from a file, e.g. one written by a secrets
+agent. The file is read again after a 401, so it can be rotated in place.
+
+On Unix the file must not be accessible by group or others (chmod 600),
+otherwise Token fails.
+*/
+type FileTokenSource struct {
+	path  string
+	cache cachedToken
+}
+
+func NewFileTokenSource(path string) *FileTokenSource {
+	return &FileTokenSource{path: path}
+}
+
+func (s *FileTokenSource) Token(ctx context.Context) (string, error) {
+	return s.cache.get(s.read)
+}
+
+func (s *FileTokenSource) Invalidate() {
+	s.cache.Invalidate()
+}
+
+func (s *FileTokenSource) read() (string, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return "", fmt.Errorf("auth token file: %w", err)
+	}
+
+	// Windows has no unix permission bits, access is governed by ACLs
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return "", fmt.Errorf("auth token file %s is accessible by other users (mode %s), run chmod 600 %s", s.path, info.Mode().Perm(), s.path)
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", fmt.Errorf("auth token file: %w", err)
+	}
+
+	log.Debug().Msgf("read auth token from %s", s.path)
+	return string(data), nil
+}
+
+/*
+CommandTokenSource runs an external command, e.g. a vault CLI, and uses its
+standard output as token. The command line is run by the shell (sh -c, or
+cmd /C on Windows). The token is cached for ttl, a ttl of 0 or less keeps it
+until the service rejects it with a 401.
+*/
+type CommandTokenSource struct {
+	command string
+	cache   cachedToken
+}
+
+func NewCommandTokenSource(command string, ttl time.Duration) *CommandTokenSource {
+	return &CommandTokenSource{command: command, cache: cachedToken{ttl: ttl}}
+}
+
+func (s *CommandTokenSource) Token(ctx context.Context) (string, error) {
+	return s.cache.get(func() (string, error) {
+		return s.run(ctx)
+	})
+}
+
+func (s *CommandTokenSource) Invalidate() {
+	s.cache.Invalidate()
+}
+
+func (s *CommandTokenSource) run(ctx context.Context) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", s.command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", s.command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	log.Debug().Msg("running auth token command")
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("auth token command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,226 @@
+package otlh_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	otlh "github.com/xifanyan/otlh/pkg"
+	"github.com/xifanyan/otlh/pkg/otlhtest"
+)
+
+func TestFileTokenSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		mode    os.FileMode
+		want    string
+		wantErr string
+	}{
+		{name: "whitespace trimmed", content: "  secret\n", mode: 0600, want: "secret"},
+		{name: "readable by group", content: "secret", mode: 0640, wantErr: "accessible by other users"},
+		{name: "readable by others", content: "secret", mode: 0604, wantErr: "accessible by other users"},
+		{name: "empty", content: " \n", mode: 0600, wantErr: "empty auth token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if runtime.GOOS == "windows" && tt.mode&0077 != 0 {
+				t.Skip("windows has no unix permission bits")
+			}
+
+			path := filepath.Join(t.TempDir(), "token")
+			if err := os.WriteFile(path, []byte(tt.content), tt.mode); err != nil {
+				t.Fatal(err)
+			}
+			// WriteFile is subject to the umask
+			if err := os.Chmod(path, tt.mode); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := otlh.NewFileTokenSource(path).Token(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("got token %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandTokenSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands are written for sh")
+	}
+
+	tests := []struct {
+		name       string
+		ttl        time.Duration
+		invalidate bool
+		want       []string
+	}{
+		{name: "cached for ttl", ttl: time.Hour, want: []string{"1", "1"}},
+		{name: "run again once expired", ttl: time.Nanosecond, want: []string{"1", "2"}},
+		{name: "kept without ttl", want: []string{"1", "1"}},
+		{name: "run again once invalidated", ttl: time.Hour, invalidate: true, want: []string{"1", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// every run appends a line to runs and prints the number of runs
+			runs := filepath.Join(t.TempDir(), "runs")
+			ts := otlh.NewCommandTokenSource("echo run >> "+runs+" && wc -l < "+runs, tt.ttl)
+
+			var got []string
+			for range tt.want {
+				token, err := ts.Token(context.Background())
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, token)
+				if tt.invalidate {
+					ts.Invalidate()
+				}
+				time.Sleep(time.Millisecond)
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("got tokens %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandTokenSourceFails(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands are written for sh")
+	}
+
+	tests := []struct {
+		name    string
+		command string
+		wantErr string
+	}{
+		{name: "exit status", command: "echo vault is sealed >&2; exit 3", wantErr: "vault is sealed"},
+		{name: "no output", command: "true", wantErr: "empty auth token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := otlh.NewCommandTokenSource(tt.command, time.Hour).Token(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// rotatingToken is a RefreshableTokenSource handing out tokens in turn, the last one forever.
+type rotatingToken struct {
+	tokens        []string
+	invalidations int
+}
+
+func (r *rotatingToken) Token(ctx context.Context) (string, error) {
+	return r.tokens[min(r.invalidations, len(r.tokens)-1)], nil
+}
+
+func (r *rotatingToken) Invalidate() {
+	r.invalidations++
+}
+
+func TestSendRefreshesRejectedToken(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	tests := []struct {
+		name              string
+		ts                otlh.TokenSource
+		wantRequests      int
+		wantInvalidations int
+		wantStatus        int
+	}{
+		{
+			name:              "expired token refreshed",
+			ts:                &rotatingToken{tokens: []string{"expired", otlhtest.AUTH_TOKEN}},
+			wantRequests:      2,
+			wantInvalidations: 1,
+		},
+		{
+			name:              "refreshed once only",
+			ts:                &rotatingToken{tokens: []string{"expired", "revoked"}},
+			wantRequests:      2,
+			wantInvalidations: 1,
+			wantStatus:        http.StatusUnauthorized,
+		},
+		{
+			name:         "static token not retried",
+			ts:           otlh.StaticToken("expired"),
+			wantRequests: 1,
+			wantStatus:   http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := srv.ClientBuilder().WithTokenSource(tt.ts).WithRetryPolicy(otlh.RetryPolicy{MaxAttempts: 1}).Build()
+			req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Folder().Build()
+
+			sent := len(srv.Requests())
+			_, err := client.GetFolders(req)
+
+			if n := len(srv.Requests()) - sent; n != tt.wantRequests {
+				t.Fatalf("got %d requests, want %d", n, tt.wantRequests)
+			}
+			if r, ok := tt.ts.(*rotatingToken); ok && r.invalidations != tt.wantInvalidations {
+				t.Fatalf("got %d invalidations, want %d", r.invalidations, tt.wantInvalidations)
+			}
+
+			var apiErr *otlh.APIError
+			switch {
+			case tt.wantStatus == 0 && err != nil:
+				t.Fatalf("got error %v, want success", err)
+			case tt.wantStatus != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus):
+				t.Fatalf("got error %v, want status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestFileTokenSourceRotatedInPlace(t *testing.T) {
+	srv := otlhtest.NewServer("demo")
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("expired\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := srv.ClientBuilder().WithTokenSource(otlh.NewFileTokenSource(path)).WithRetryPolicy(otlh.RetryPolicy{MaxAttempts: 1}).Build()
+	req, _ := otlh.NewRequest().WithTenant(client.Tenant()).Get().Folder().Build()
+
+	if _, err := client.GetFolders(req); err == nil {
+		t.Fatal("got success with an expired token in the file")
+	}
+
+	// the cached token is rejected, the file is read again
+	if err := os.WriteFile(path, []byte(otlhtest.AUTH_TOKEN+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetFolders(req); err != nil {
+		t.Fatalf("got %v after rotating the token file", err)
+	}
+}
@@ -44,17 +44,17 @@ type CustodianInput struct {
 	EmployeeID      string `csv:"EmployeeID"`
 	Name            string `csv:"Name"`
 	Emailaddress    string `csv:"Emailaddress"`
-	EmployeeStatus  string `csv:"EmployeeStatus`
-	EmployeeType    string `csv:"EmployeeType`
+	EmployeeStatus  string `csv:"EmployeeStatus"`
+	EmployeeType    string `csv:"EmployeeType"`
 	Title           string `csv:"Title"`
-	OfficePhone     string `csv:"OfficePhone`
-	Department      string `csv:"Department`
-	Location        string `csv:"Location`
-	SupervisorName  string `csv:"SupervisorName`
-	SupervisorEmail string `csv:"SupervisorEmail`
-	Function        string `csv:"Function`
-	Business        string `csv:"Business`
-	Notes           string `csv:"Notes`
+	OfficePhone     string `csv:"OfficePhone"`
+	Department      string `csv:"Department"`
+	Location        string `csv:"Location"`
+	SupervisorName  string `csv:"SupervisorName"`
+	SupervisorEmail string `csv:"SupervisorEmail"`
+	Function        string `csv:"Function"`
+	Business        string `csv:"Business"`
+	Notes           string `csv:"Notes"`
 }
 
 func (cv *CustodianVerifier) LoadCustodiansFromCSV(fileName string) (map[string]struct{}, error) {